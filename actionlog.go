@@ -0,0 +1,88 @@
+package blackjack
+
+// LoggedAction is an action in a round's table-wide action log, along with who
+// took it and on which hand
+type LoggedAction struct {
+	Player string `json:"player,omitempty"` // Player is the name of the player who took the action (empty for the dealer)
+	Hand   int    `json:"hand"`             // Hand is the index of the player's hand the action was taken on
+	Action Action `json:"action"`           // Action is the action taken
+}
+
+// IsDealer returns true if the action was taken on the dealer's hand
+func (a LoggedAction) IsDealer() bool {
+	return a.Player == ""
+}
+
+// ActionLog returns the actions taken so far in the current round by every player
+// and the dealer, in the order they were taken, or nil if no round is in progress.
+// The dealer's hole card is omitted until it has been revealed. The log of a
+// completed round is kept in its RoundRecord.
+func (bg *Game) ActionLog() []LoggedAction {
+	if bg.record == nil {
+		return nil
+	}
+	log := copyLog(bg.record.Log)
+	if !bg.dealer.holeCardRevealed {
+		deals := 0
+		for i, entry := range log {
+			if !entry.IsDealer() || entry.Action.Type != ActionDeal {
+				continue
+			}
+			deals++
+			if deals == 2 {
+				log[i].Action.Card = nil
+				log[i].Action.Value = 0
+				break
+			}
+		}
+	}
+	return log
+}
+
+// logAction adds an action to the log of the round in progress
+func (bg *Game) logAction(player string, hand int, action Action) {
+	if bg.record == nil {
+		return
+	}
+	bg.record.Log = append(bg.record.Log, LoggedAction{
+		Player: player,
+		Hand:   hand,
+		Action: copyActions([]Action{action})[0],
+	})
+}
+
+// logAction adds the player's action on the hand to the game's action log
+func (p *Player) logAction(hand *Hand, action Action) {
+	if p.game == nil {
+		return
+	}
+	idx := len(p.hands) // a hand created by a split is added to the player's hands after its first actions
+	for i, h := range p.hands {
+		if h == hand {
+			idx = i
+			break
+		}
+	}
+	p.game.logAction(p.name, idx, action)
+}
+
+// logAction adds the dealer's most recent action to the game's action log
+func (d *Dealer) logAction() {
+	if d.game == nil || len(d.hand.actions) == 0 {
+		return
+	}
+	d.game.logAction("", 0, d.hand.actions[len(d.hand.actions)-1])
+}
+
+// copyLog returns a deep copy of the action log
+func copyLog(log []LoggedAction) []LoggedAction {
+	if log == nil {
+		return nil
+	}
+	result := make([]LoggedAction, len(log))
+	for i, entry := range log {
+		entry.Action = copyActions([]Action{entry.Action})[0]
+		result[i] = entry
+	}
+	return result
+}
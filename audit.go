@@ -0,0 +1,278 @@
+package blackjack
+
+import (
+	"fmt"
+	"slices"
+)
+
+// AuditViolation describes an action in a round's log that was not legal at the
+// time it occurred
+type AuditViolation struct {
+	Step   int    `json:"step"`   // Step is the position of the action in the round's time-ordered log
+	Player string `json:"player"` // Player is the name of the player who took the action ("Dealer" for the dealer)
+	Hand   int    `json:"hand"`   // Hand is the index of the player's hand the action was taken on
+	Action Action `json:"action"` // Action is the offending action
+	Reason string `json:"reason"` // Reason describes why the action was not legal
+}
+
+// String returns a string representation of the violation
+func (v AuditViolation) String() string {
+	return fmt.Sprintf("step %d: %s hand %d %s: %s", v.Step+1, v.Player, v.Hand+1, v.Action.Type, v.Reason)
+}
+
+// auditor tracks the state needed to re-validate a round as it is replayed
+type auditor struct {
+	replayer      *Replayer
+	rules         Rules          // rules are the table rules the round was played under
+	balances      []int          // balances are each seat's chips not yet committed to bets
+	doubled       map[*Hand]bool // doubled tracks the hands that have been doubled down
+	cardIdx       int            // cardIdx is the position of the next card drawn from the shoe
+	dealerStarted bool           // dealerStarted is whether the dealer has begun playing their hand
+	violations    []AuditViolation
+}
+
+// AuditRound re-validates every action in a recorded round, verifying that each
+// action was legal when it was taken: cards match the order they were drawn from
+// the shoe, hands are acted on in turn, the dealer only plays once every player
+// hand is finished, and players had sufficient chips for each additional bet. The
+// returned slice is empty if the round is valid. Actions are checked against the
+// rules recorded with the round, or the default rules if none were recorded.
+func AuditRound(record *RoundRecord) []AuditViolation {
+	a := &auditor{
+		replayer: NewReplayer(record),
+		rules:    record.rules(),
+		balances: make([]int, len(record.Seats)),
+		doubled:  make(map[*Hand]bool),
+	}
+	for i, seat := range record.Seats {
+		a.balances[i] = seat.Chips
+		for _, bet := range seat.Bets {
+			a.balances[i] -= bet
+		}
+		if a.balances[i] < 0 {
+			a.violate(0, record.Seats[i].Name, 0, Action{}, "initial bets exceed the player's chips")
+		}
+	}
+
+	for !a.replayer.Done() {
+		step := a.replayer.steps[a.replayer.pos]
+		a.check(step)
+		if _, err := a.replayer.Step(); err != nil {
+			a.violate(a.replayer.pos, a.name(step), step.hand, step.action, err.Error())
+			a.replayer.pos++
+		}
+	}
+
+	return a.violations
+}
+
+// violate records a violation
+func (a *auditor) violate(pos int, player string, hand int, action Action, reason string) {
+	a.violations = append(a.violations, AuditViolation{
+		Step:   pos,
+		Player: player,
+		Hand:   hand,
+		Action: action,
+		Reason: reason,
+	})
+}
+
+// name returns the name of the player who took the step
+func (a *auditor) name(step replayStep) string {
+	if step.seat < 0 {
+		return "Dealer"
+	}
+	return a.replayer.record.Seats[step.seat].Name
+}
+
+// check validates a single step against the state of the round before it is applied
+func (a *auditor) check(step replayStep) {
+	pos := a.replayer.pos
+	action := step.action
+	fail := func(format string, args ...any) {
+		a.violate(pos, a.name(step), step.hand, action, fmt.Sprintf(format, args...))
+	}
+
+	a.checkCard(action, fail)
+
+	if step.seat < 0 {
+		a.checkDealer(action, fail)
+		return
+	}
+
+	player := a.replayer.players[step.seat]
+	if step.hand > len(player.hands) {
+		fail("hand does not exist")
+		return
+	}
+	if step.hand == len(player.hands) {
+		// The first action on a hand created by a split is the card moved to it
+		if action.Type != ActionDeal {
+			fail("hand was acted on before it was created")
+		}
+		return
+	}
+	hand := player.hands[step.hand]
+
+	if a.dealerStarted && action.Type != ActionBust {
+		fail("player acted after the dealer's turn began")
+	}
+	if isDecision(action) && !a.isHandsTurn(player, hand) {
+		fail("another hand was still being played")
+	}
+
+	switch action.Type {
+	case ActionDeal:
+		if hand.Count() >= 2 {
+			fail("hand already had %d cards", hand.Count())
+		}
+	case ActionHit:
+		switch {
+		case hand.Count() < 2:
+			fail("hand was hit before the initial deal was complete")
+		case isFinished(hand):
+			fail("hand was already finished")
+		case hand.Value() >= 21:
+			fail("hand was hit with a value of %d", hand.Value())
+		case a.rules.CharlieCards > 0 && hand.Count() >= a.rules.CharlieCards:
+			fail("hand was hit after reaching a %d-card Charlie", hand.Count())
+		case !a.rules.AllowsHit(hand):
+			fail("split aces may not be hit")
+		}
+	case ActionDouble:
+		if action.Card == nil {
+			switch {
+			case hand.Count() != 2:
+				fail("hand had %d cards", hand.Count())
+			case a.doubled[hand] || isFinished(hand):
+				fail("hand was already finished")
+			case !a.rules.AllowsDouble(hand):
+				fail("the table rules do not allow doubling down on %d", hand.Value())
+			case a.balances[step.seat] < hand.Bet():
+				fail("insufficient chips: have %d, need %d", a.balances[step.seat], hand.Bet())
+			default:
+				a.balances[step.seat] -= hand.Bet()
+			}
+			a.doubled[hand] = true
+		} else if !a.doubled[hand] || hand.Count() != 2 {
+			fail("double down card dealt without doubling the bet")
+		}
+	case ActionStand:
+		if hand.IsStood() {
+			fail("hand was already stood")
+		}
+	case ActionSplit:
+		if hand.Count() != 2 {
+			// The new hand records that it was created from a split
+			return
+		}
+		switch {
+		case isFinished(hand):
+			fail("hand was already finished")
+		case !hand.IsPair():
+			fail("cards were not a pair")
+		case player.spotHandCount(hand.spot) >= a.rules.maxSplitHands():
+			fail("spot already had %d hands", player.spotHandCount(hand.spot))
+		case hand.isSplitAces() && !a.rules.ResplitAces:
+			fail("split aces may not be split again")
+		case a.balances[step.seat] < hand.Bet():
+			fail("insufficient chips: have %d, need %d", a.balances[step.seat], hand.Bet())
+		default:
+			a.balances[step.seat] -= hand.Bet()
+		}
+	case ActionSurrender:
+		switch {
+		case a.rules.NoSurrender:
+			fail("the table rules do not allow surrender")
+		case hand.IsSplit() && !a.rules.SurrenderAfterSplit:
+			fail("the table rules do not allow surrender after a split")
+		case hand.Count() != 2:
+			fail("only a two-card hand may be surrendered")
+		case isFinished(hand):
+			fail("hand was already finished")
+		case slices.ContainsFunc(hand.actions, isDecision):
+			fail("hand had already been acted on")
+		}
+	case ActionBust:
+		if !hand.IsBusted() {
+			fail("hand was not busted")
+		}
+	}
+}
+
+// checkDealer validates an action on the dealer's hand
+func (a *auditor) checkDealer(action Action, fail func(string, ...any)) {
+	dealer := a.replayer.dealer
+	switch action.Type {
+	case ActionDeal:
+		if dealer.Hand().Count() >= 2 {
+			fail("dealer already had %d cards", dealer.Hand().Count())
+		}
+	case ActionHit, ActionStand:
+		a.dealerStarted = true
+		for _, player := range a.replayer.players {
+			for _, hand := range player.hands {
+				if !isFinished(hand) {
+					fail("dealer played before %s finished their hands", player.Name())
+					return
+				}
+			}
+		}
+		shouldHit := dealer.shouldHit(a.rules.StandSoft17)
+		if action.Type == ActionHit && !shouldHit {
+			fail("dealer hit on %d", dealer.Value())
+		}
+		if action.Type == ActionStand && shouldHit {
+			fail("dealer stood on %d", dealer.Value())
+		}
+	}
+}
+
+// checkCard verifies that a card dealt by the action was the next card drawn from the shoe
+func (a *auditor) checkCard(action Action, fail func(string, ...any)) {
+	if action.Card == nil || (action.Type == ActionDeal && action.Details == "split card") {
+		// Split cards are moved from the original hand rather than drawn
+		return
+	}
+	cards := a.replayer.record.Cards
+	if a.cardIdx >= len(cards) {
+		fail("%s was never drawn from the shoe", action.Card)
+		return
+	}
+	if expected := cards[a.cardIdx]; expected != *action.Card {
+		fail("%s was dealt but %s was drawn from the shoe", action.Card, expected)
+	}
+	a.cardIdx++
+}
+
+// isHandsTurn returns true if every hand the player plays before the given hand is finished
+func (a *auditor) isHandsTurn(player *Player, hand *Hand) bool {
+	for idx, other := range player.hands {
+		if other == hand {
+			continue
+		}
+		before := other.spot < hand.spot || (other.spot == hand.spot && idx < slices.Index(player.hands, hand))
+		if before && !isFinished(other) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDecision returns true if the action is a choice made by the player rather than
+// a card dealt or a consequence of another action
+func isDecision(action Action) bool {
+	switch action.Type {
+	case ActionHit, ActionDouble, ActionSurrender:
+		return true
+	case ActionSplit:
+		return action.Details != "created from split"
+	default:
+		return false
+	}
+}
+
+// isFinished returns true if no further actions may be taken on the hand
+func isFinished(hand *Hand) bool {
+	return hand.IsStood() || hand.IsBusted() || hand.IsSurrendered() || hand.IsBlackjack()
+}
@@ -0,0 +1,199 @@
+package blackjack
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Bankroll is a pool of chips shared by a team of players. Each player plays from
+// the bankroll through their own BankrollMember, which tracks how much of the
+// bankroll the player currently has at risk (their exposure) and how much they
+// have won or lost for the team. The bankroll is safe for concurrent use, so
+// players at different tables may share it.
+type Bankroll struct {
+	mu      sync.Mutex
+	chips   int
+	members map[string]*BankrollMember
+}
+
+// BankrollMember is a player's share of a Bankroll. It is a ChipManager whose
+// chips are the bankroll's chips. Bets are held in escrow so that each member's
+// exposure is known until their hands are settled.
+type BankrollMember struct {
+	bankroll *Bankroll
+	name     string
+	exposure int // exposure is the amount of the bankroll the member has riding on unsettled bets
+	net      int // net is the amount the member has won (or lost, if negative) for the bankroll
+}
+
+// NewBankroll creates a shared bankroll with the given chips
+func NewBankroll(chips int) *Bankroll {
+	return &Bankroll{chips: chips, members: make(map[string]*BankrollMember)}
+}
+
+// WithBankroll makes the player play from a shared bankroll. Options that set the
+// player's chips, such as WithChips, set the chips of the whole bankroll.
+func WithBankroll(bankroll *Bankroll) Option {
+	return func(p *Player) {
+		p.chipManager = bankroll.Member(p.name)
+		p.contextChips = nil
+	}
+}
+
+// Member returns the named member of the bankroll, adding them if they are not
+// already a member
+func (b *Bankroll) Member(name string) *BankrollMember {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	member, ok := b.members[name]
+	if !ok {
+		member = &BankrollMember{bankroll: b, name: name}
+		b.members[name] = member
+	}
+	return member
+}
+
+// Members returns the names of the bankroll's members, in alphabetical order
+func (b *Bankroll) Members() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	names := make([]string, 0, len(b.members))
+	for name := range b.members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Chips returns the chips available in the bankroll, not counting chips riding
+// on unsettled bets
+func (b *Bankroll) Chips() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.chips
+}
+
+// Exposure returns the total amount of the bankroll riding on unsettled bets
+func (b *Bankroll) Exposure() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	total := 0
+	for _, member := range b.members {
+		total += member.exposure
+	}
+	return total
+}
+
+// rename changes the name the member is known by in the bankroll
+func (b *Bankroll) rename(member *BankrollMember, name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if other, ok := b.members[name]; ok && other != member {
+		return fmt.Errorf("bankroll already has a member named %s", name)
+	}
+	if b.members[member.name] == member {
+		delete(b.members, member.name)
+	}
+	member.name = name
+	b.members[name] = member
+	return nil
+}
+
+// Name returns the name of the member
+func (m *BankrollMember) Name() string {
+	return m.name
+}
+
+// Exposure returns the amount of the bankroll the member has riding on unsettled bets
+func (m *BankrollMember) Exposure() int {
+	m.bankroll.mu.Lock()
+	defer m.bankroll.mu.Unlock()
+	return m.exposure
+}
+
+// Net returns the amount the member has won (or lost, if negative) for the bankroll
+func (m *BankrollMember) Net() int {
+	m.bankroll.mu.Lock()
+	defer m.bankroll.mu.Unlock()
+	return m.net
+}
+
+// GetChips returns the chips available in the bankroll
+func (m *BankrollMember) GetChips() int {
+	return m.bankroll.Chips()
+}
+
+// SetChips sets the chips available in the bankroll. The change is not attributed
+// to the member.
+func (m *BankrollMember) SetChips(amount int) {
+	m.bankroll.mu.Lock()
+	defer m.bankroll.mu.Unlock()
+	m.bankroll.chips = amount
+}
+
+// AddChips adds chips won by the member to the bankroll
+func (m *BankrollMember) AddChips(amount int) {
+	m.bankroll.mu.Lock()
+	defer m.bankroll.mu.Unlock()
+	m.bankroll.chips += amount
+	m.net += amount
+}
+
+// DeductChips removes chips lost by the member from the bankroll
+func (m *BankrollMember) DeductChips(amount int) error {
+	m.bankroll.mu.Lock()
+	defer m.bankroll.mu.Unlock()
+	if amount > m.bankroll.chips {
+		return fmt.Errorf("insufficient chips in bankroll: have %d, need %d", m.bankroll.chips, amount)
+	}
+	m.bankroll.chips -= amount
+	m.net -= amount
+	return nil
+}
+
+// HasEnoughChips returns true if the bankroll has enough chips for the specified amount
+func (m *BankrollMember) HasEnoughChips(amount int) bool {
+	return m.bankroll.Chips() >= amount
+}
+
+// Reserve moves a bet placed by the member from the bankroll into escrow
+func (m *BankrollMember) Reserve(amount int) error {
+	m.bankroll.mu.Lock()
+	defer m.bankroll.mu.Unlock()
+	if amount > m.bankroll.chips {
+		return fmt.Errorf("insufficient chips in bankroll: have %d, need %d", m.bankroll.chips, amount)
+	}
+	m.bankroll.chips -= amount
+	m.exposure += amount
+	return nil
+}
+
+// Commit finalizes a bet held in escrow as spent by the member
+func (m *BankrollMember) Commit(amount int) error {
+	m.bankroll.mu.Lock()
+	defer m.bankroll.mu.Unlock()
+	if amount > m.exposure {
+		return fmt.Errorf("cannot commit %d chips: %s only has %d reserved", amount, m.name, m.exposure)
+	}
+	m.exposure -= amount
+	m.net -= amount
+	return nil
+}
+
+// Release returns a bet held in escrow to the bankroll
+func (m *BankrollMember) Release(amount int) error {
+	m.bankroll.mu.Lock()
+	defer m.bankroll.mu.Unlock()
+	if amount > m.exposure {
+		return fmt.Errorf("cannot release %d chips: %s only has %d reserved", amount, m.name, m.exposure)
+	}
+	m.exposure -= amount
+	m.bankroll.chips += amount
+	return nil
+}
+
+// Reserved returns the amount the member has held in escrow
+func (m *BankrollMember) Reserved() int {
+	return m.Exposure()
+}
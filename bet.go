@@ -0,0 +1,75 @@
+package blackjack
+
+import "maps"
+
+// BetComponents breaks a hand's bet down into the wagers that make it up, so that
+// refunds and winnings can be attributed to each wager
+type BetComponents struct {
+	Original  int            `json:"original"`            // Original is the bet placed before the cards were dealt
+	Double    int            `json:"double,omitempty"`    // Double is the amount added by doubling down
+	Insurance int            `json:"insurance,omitempty"` // Insurance is the insurance wager against a dealer blackjack
+	SideBets  map[string]int `json:"side_bets,omitempty"` // SideBets are any side wagers, by name
+}
+
+// Main returns the wager on the hand itself: the original bet and any double down
+func (b BetComponents) Main() int {
+	return b.Original + b.Double
+}
+
+// Total returns the sum of every wager on the hand
+func (b BetComponents) Total() int {
+	total := b.Main() + b.Insurance
+	for _, amount := range b.SideBets {
+		total += amount
+	}
+	return total
+}
+
+// clone returns a copy of the components that shares no memory with them
+func (b BetComponents) clone() BetComponents {
+	b.SideBets = maps.Clone(b.SideBets)
+	return b
+}
+
+// Bets returns the components of the hand's bet
+func (h *Hand) Bets() BetComponents {
+	return h.bets.clone()
+}
+
+// WinningsBreakdown breaks a hand's net winnings down by the wager they came from
+type WinningsBreakdown struct {
+	Main           int            `json:"main"`                      // Main is the amount won or lost on the main bet at even money
+	BlackjackBonus int            `json:"blackjack_bonus,omitempty"` // BlackjackBonus is the amount paid for a blackjack beyond even money
+	Insurance      int            `json:"insurance,omitempty"`       // Insurance is the amount won or lost on insurance
+	SideBets       map[string]int `json:"side_bets,omitempty"`       // SideBets are the amounts won or lost on side bets, by name
+}
+
+// Net returns the total amount won (negative for a loss) across every wager
+func (w WinningsBreakdown) Net() int {
+	net := w.Main + w.BlackjackBonus + w.Insurance
+	for _, amount := range w.SideBets {
+		net += amount
+	}
+	return net
+}
+
+// breakdownWinnings breaks down the net winnings of a hand with the given bet and
+// result. Winnings beyond even money on a blackjack are the blackjack bonus.
+func breakdownWinnings(winnings int, bet int, result GameResult) WinningsBreakdown {
+	if result == PlayerBlackjack && winnings > bet {
+		return WinningsBreakdown{Main: bet, BlackjackBonus: winnings - bet}
+	}
+	return WinningsBreakdown{Main: winnings}
+}
+
+// WinningsBreakdown returns the hand's net winnings broken down by the wager they
+// came from
+func (h *Hand) WinningsBreakdown() WinningsBreakdown {
+	return breakdownWinnings(h.winnings, h.Bet(), h.result)
+}
+
+// WinningsBreakdown returns the hand's net winnings broken down by the wager they
+// came from
+func (h HandRecord) WinningsBreakdown() WinningsBreakdown {
+	return breakdownWinnings(h.Winnings, h.Bet, h.Result)
+}
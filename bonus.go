@@ -0,0 +1,94 @@
+package blackjack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBonusUnavailable is returned when a player claims a bonus they may not have
+var ErrBonusUnavailable = errors.New("bonus unavailable")
+
+// Bonus configures the free chips players may claim periodically, so that players
+// who run out of chips can keep playing
+type Bonus struct {
+	Amount    int           `json:"amount"`              // Amount is the number of chips granted by each claim
+	Cooldown  time.Duration `json:"cooldown"`            // Cooldown is how long a player must wait between claims
+	Threshold int           `json:"threshold,omitempty"` // Threshold is the balance a player must be below to claim (0 for any balance)
+}
+
+// DailyBonus returns a bonus of the amount that may be claimed once a day by
+// players who are out of chips
+func DailyBonus(amount int) Bonus {
+	return Bonus{Amount: amount, Cooldown: 24 * time.Hour, Threshold: 1}
+}
+
+// WithBonus lets players claim free chips with ClaimBonus
+func WithBonus(bonus Bonus) GameOption {
+	return func(g *Game) {
+		g.bonus = bonus
+	}
+}
+
+// SetBonus changes the free chips players may claim. A bonus with no amount
+// disables claims.
+func (bg *Game) SetBonus(bonus Bonus) {
+	bg.bonus = bonus
+}
+
+// NextBonus returns when the player may next claim a bonus, ignoring the balance
+// threshold. A zero time means the player may claim now.
+func (bg *Game) NextBonus(playerName string) (time.Time, error) {
+	player := bg.GetPlayer(playerName)
+	if player == nil {
+		return time.Time{}, fmt.Errorf("player %s not found", playerName)
+	}
+	if player.lastBonus.IsZero() {
+		return time.Time{}, nil
+	}
+	next := player.lastBonus.Add(bg.bonus.Cooldown)
+	if !next.After(time.Now()) {
+		return time.Time{}, nil
+	}
+	return next, nil
+}
+
+// ClaimBonus grants the game's bonus chips to the player, returning the amount
+// granted. It fails with ErrBonusUnavailable if the game has no bonus, the player
+// claimed one within the cooldown, or the player's balance is not below the
+// bonus threshold.
+func (bg *Game) ClaimBonus(playerName string) (int, error) {
+	player := bg.GetPlayer(playerName)
+	if player == nil {
+		return 0, fmt.Errorf("player %s not found", playerName)
+	}
+	if bg.bonus.Amount <= 0 {
+		return 0, fmt.Errorf("the table does not offer a bonus: %w", ErrBonusUnavailable)
+	}
+	if bg.bonus.Threshold > 0 && player.Chips() >= bg.bonus.Threshold {
+		return 0, fmt.Errorf("player %s must have fewer than %d chips to claim a bonus: %w",
+			playerName, bg.bonus.Threshold, ErrBonusUnavailable)
+	}
+	now := time.Now()
+	if !player.lastBonus.IsZero() {
+		if wait := player.lastBonus.Add(bg.bonus.Cooldown).Sub(now); wait > 0 {
+			return 0, fmt.Errorf("player %s must wait %s to claim another bonus: %w",
+				playerName, wait.Round(time.Second), ErrBonusUnavailable)
+		}
+	}
+
+	if err := player.creditChips(context.Background(), bg.bonus.Amount, ChipReasonBonus); err != nil {
+		return 0, fmt.Errorf("failed to grant bonus to %s: %w", playerName, err)
+	}
+	player.lastBonus = now
+	bg.log().Info("bonus claimed", "player", playerName, "amount", bg.bonus.Amount)
+	bg.emit(Event{
+		Type:    EventBonusClaimed,
+		Player:  playerName,
+		Amount:  bg.bonus.Amount,
+		Balance: player.Chips(),
+		Reason:  ChipReasonBonus,
+	})
+	return bg.bonus.Amount, nil
+}
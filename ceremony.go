@@ -0,0 +1,52 @@
+package blackjack
+
+import "fmt"
+
+// Cutter chooses where a freshly shuffled shoe is cut, so a frontend emulating a
+// live dealer can let a player place the cut card
+type Cutter interface {
+	// Cut returns the number of cards moved from the top of the shoe to the bottom.
+	// A position outside 1 through cards-1 leaves the shoe uncut.
+	Cut(cards int) int
+}
+
+// CutterFunc adapts an ordinary function to the Cutter interface
+type CutterFunc func(cards int) int
+
+// Cut calls f(cards)
+func (f CutterFunc) Cut(cards int) int {
+	return f(cards)
+}
+
+// WithCutter lets the cutter cut the shoe each time the game reshuffles it
+func WithCutter(cutter Cutter) GameOption {
+	return func(g *Game) {
+		g.cutter = cutter
+	}
+}
+
+// WithBurnCards burns the given number of cards from the top of the shoe each time
+// the game reshuffles it. Each burned card is shown face up with EventCardBurned.
+func WithBurnCards(burn int) GameOption {
+	return func(g *Game) {
+		g.burn = max(0, burn)
+	}
+}
+
+// reshuffle reshuffles the shoe for the given reason, then has it cut and burns
+// the top cards as the game is configured to
+func (bg *Game) reshuffle(reason string) {
+	bg.log().Debug("reshuffling shoe", "reason", reason)
+	bg.shoe.Reshuffle()
+	bg.emit(Event{Type: EventShoeShuffled, Details: reason})
+	if bg.cutter != nil {
+		position := bg.cutter.Cut(bg.shoe.CardsRemaining())
+		if err := bg.shoe.Cut(position); err != nil {
+			bg.log().Warn("shoe left uncut", "position", position, "error", err)
+		}
+	}
+	for i := 0; i < bg.burn && !bg.shoe.IsEmpty(); i++ {
+		card := bg.shoe.cards.Draw()
+		bg.emit(Event{Type: EventCardBurned, Card: &card, Details: fmt.Sprintf("burn card %d", i+1)})
+	}
+}
@@ -0,0 +1,142 @@
+package blackjack
+
+import "fmt"
+
+// ChipViolation describes a player whose chips don't add up: their balance, along
+// with any bets still riding, differs from what the bets and results of the rounds
+// played account for
+type ChipViolation struct {
+	Round    int       `json:"round"`    // Round is the number of the round being played when the check failed
+	Event    EventType `json:"event"`    // Event is the event the check was made at
+	Player   string    `json:"player"`   // Player is the name of the player whose chips don't add up
+	Expected int       `json:"expected"` // Expected is the chips the player should hold, including bets riding
+	Actual   int       `json:"actual"`   // Actual is the chips the player holds, including bets riding
+}
+
+// String returns a string representation of the violation
+func (v ChipViolation) String() string {
+	return fmt.Sprintf("round %d %s: %s holds %d chips, expected %d", v.Round, v.Event, v.Player, v.Actual, v.Expected)
+}
+
+// chipChecker is a listener that verifies every player's chips are conserved: a
+// player's chips plus their outstanding bets only change when a hand is settled or
+// chips are added from outside the round, such as by a rebuy or a bonus
+type chipChecker struct {
+	game        *Game
+	report      func(ChipViolation)
+	expected    map[string]int // expected are the chips each player should hold, including bets riding
+	surrendered map[string]int // surrendered are the chips each player has lost surrendering hands in the round in progress
+}
+
+// WithChipChecks turns on a validation mode, for tests and debugging, that checks
+// chips are conserved throughout every round. Whenever a round starts, a card is
+// dealt, a turn ends, or a round is settled or abandoned, each player's chips plus
+// their outstanding bets are compared with their balance before the round adjusted
+// by the results of the hands settled, catching settlement bugs such as a hand
+// being paid twice. Each violation is passed to report, or logged as an error if
+// report is nil. The checks assume that only the game changes the players' chips.
+func WithChipChecks(report func(ChipViolation)) GameOption {
+	return func(g *Game) {
+		g.listeners = append(g.listeners, &chipChecker{
+			game:        g,
+			report:      report,
+			expected:    make(map[string]int),
+			surrendered: make(map[string]int),
+		})
+	}
+}
+
+// OnEvent adjusts the chips each player is expected to hold and checks them at the
+// points in a round where no chips are moving between a player and their bets
+func (c *chipChecker) OnEvent(event Event) {
+	switch event.Type {
+	case EventChipsChanged:
+		switch event.Reason {
+		case ChipReasonRebuy, ChipReasonReset, ChipReasonBonus:
+			if _, ok := c.expected[event.Player]; ok {
+				c.expected[event.Player] += event.Amount
+			}
+		}
+	case EventPlayerJoined:
+		if player := c.game.GetPlayer(event.Player); player != nil {
+			c.expected[player.Name()] = player.Chips()
+		}
+	case EventRoundCompleted:
+		if event.Record != nil {
+			for _, seat := range event.Record.Seats {
+				if _, ok := c.expected[seat.Name]; ok {
+					c.expected[seat.Name] += seat.Net()
+				}
+			}
+		}
+		clear(c.surrendered)
+		c.check(event.Type)
+	case EventRoundStarted, EventRoundAborted:
+		// The other bets of a round abandoned unsettled are refunded, but surrendered
+		// hands were settled when they were surrendered
+		for name, lost := range c.surrendered {
+			if _, ok := c.expected[name]; ok {
+				c.expected[name] -= lost
+			}
+		}
+		clear(c.surrendered)
+		c.check(event.Type)
+	case EventCardDealt, EventTurnEnded:
+		c.check(event.Type)
+	}
+
+	// Hands are only surrendered once the cards are dealt, and are cleared when the
+	// round ends or the next one starts
+	if phase := c.game.phase; phase == PhasePlayerTurns || phase == PhaseDealerTurn {
+		for _, player := range c.game.players {
+			c.surrendered[player.Name()] = surrenderLosses(player)
+		}
+	}
+}
+
+// check compares the chips each player holds with the chips they are expected to
+// hold, starting to track any player not seen before
+func (c *chipChecker) check(event EventType) {
+	for _, player := range c.game.players {
+		actual := c.holdings(player)
+		expected, ok := c.expected[player.Name()]
+		if !ok {
+			c.expected[player.Name()] = actual
+			continue
+		}
+		if actual == expected {
+			continue
+		}
+		violation := ChipViolation{Round: c.game.round, Event: event, Player: player.Name(), Expected: expected, Actual: actual}
+		if c.report != nil {
+			c.report(violation)
+		} else {
+			c.game.log().Error("chips not conserved", "round", violation.Round, "event", violation.Event,
+				"player", violation.Player, "expected", violation.Expected, "actual", violation.Actual)
+		}
+		// Start over from the actual balance so a single bug is reported once
+		c.expected[player.Name()] = actual
+	}
+}
+
+// holdings returns the player's chips plus the bets they have riding on the round
+// in progress. The chips lost on a surrendered hand are added back, as they are
+// accounted for once the round is over.
+func (c *chipChecker) holdings(player *Player) int {
+	chips := player.Chips()
+	if !c.game.roundInProgress() {
+		return chips
+	}
+	return chips + player.outstandingBets() + surrenderLosses(player)
+}
+
+// surrenderLosses returns the chips the player has lost surrendering hands
+func surrenderLosses(player *Player) int {
+	lost := 0
+	for _, hand := range player.hands {
+		if hand.isSurrendered {
+			lost -= hand.Winnings()
+		}
+	}
+	return lost
+}
@@ -0,0 +1,136 @@
+package blackjack
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultChipTimeout = 5 * time.Second // DefaultChipTimeout is how long a chip operation may take before it is cancelled
+)
+
+// ContextChipManager is a chip manager whose operations accept a context and report
+// failures, so that chip managers backed by a remote service (such as a database or
+// wallet API) can time out and return errors instead of silently succeeding
+type ContextChipManager interface {
+	GetChips(ctx context.Context) (int, error)                    // GetChips returns the current chip count
+	SetChips(ctx context.Context, amount int) error               // SetChips sets the chip count to the specified amount
+	AddChips(ctx context.Context, amount int) error               // AddChips adds the specified amount to the chip count
+	DeductChips(ctx context.Context, amount int) error            // DeductChips removes the specified amount from the chip count
+	HasEnoughChips(ctx context.Context, amount int) (bool, error) // HasEnoughChips returns true if there are enough chips for the specified amount
+}
+
+// AdaptChipManager returns a ContextChipManager that calls the chip manager,
+// ignoring the context
+func AdaptChipManager(cm ChipManager) ContextChipManager {
+	return chipManagerAdapter{cm: cm}
+}
+
+// chipManagerAdapter adapts a ChipManager to the ContextChipManager interface
+type chipManagerAdapter struct {
+	cm ChipManager
+}
+
+// GetChips returns the current chip count
+func (a chipManagerAdapter) GetChips(ctx context.Context) (int, error) {
+	return a.cm.GetChips(), nil
+}
+
+// SetChips sets the chip count to the specified amount
+func (a chipManagerAdapter) SetChips(ctx context.Context, amount int) error {
+	a.cm.SetChips(amount)
+	return nil
+}
+
+// AddChips adds the specified amount to the chip count
+func (a chipManagerAdapter) AddChips(ctx context.Context, amount int) error {
+	a.cm.AddChips(amount)
+	return nil
+}
+
+// DeductChips removes the specified amount from the chip count
+func (a chipManagerAdapter) DeductChips(ctx context.Context, amount int) error {
+	return a.cm.DeductChips(amount)
+}
+
+// HasEnoughChips returns true if there are enough chips for the specified amount
+func (a chipManagerAdapter) HasEnoughChips(ctx context.Context, amount int) (bool, error) {
+	return a.cm.HasEnoughChips(amount), nil
+}
+
+// WithContextChipManager sets a context-aware chip manager for the player. It is
+// used in place of any ChipManager set with WithChipManager.
+func WithContextChipManager(cm ContextChipManager) Option {
+	return func(p *Player) {
+		p.contextChips = cm
+	}
+}
+
+// WithChipTimeout sets how long each of the player's chip operations may take
+// before it is cancelled (DefaultChipTimeout if not set)
+func WithChipTimeout(timeout time.Duration) Option {
+	return func(p *Player) {
+		p.chipTimeout = timeout
+	}
+}
+
+// SetContextChipManager replaces the player's chip manager with a context-aware one
+func (p *Player) SetContextChipManager(cm ContextChipManager) {
+	p.contextChips = cm
+}
+
+// ChipsContext returns the player's current chip count, or the error reported by
+// the chip manager
+func (p *Player) ChipsContext(ctx context.Context) (int, error) {
+	ctx, cancel := p.chipContext(ctx)
+	defer cancel()
+	return p.wallet().GetChips(ctx)
+}
+
+// AddChipsContext adds chips to the player's account, returning any error reported
+// by the chip manager
+func (p *Player) AddChipsContext(ctx context.Context, amount int) error {
+	if err := p.creditChips(ctx, amount, ChipReasonRebuy); err != nil {
+		return fmt.Errorf("failed to add %d chips for %s: %w", amount, p.name, err)
+	}
+	return nil
+}
+
+// wallet returns the player's chip manager as a ContextChipManager
+func (p *Player) wallet() ContextChipManager {
+	if p.contextChips != nil {
+		return p.contextChips
+	}
+	return AdaptChipManager(p.chipManager)
+}
+
+// chipContext returns the context used for one of the player's chip operations,
+// which is cancelled with the caller's context or once the player's chip timeout
+// has passed
+func (p *Player) chipContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := p.chipTimeout
+	if timeout <= 0 {
+		timeout = DefaultChipTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// chipError logs a chip manager error that can't be returned to the caller
+func (p *Player) chipError(msg string, err error, args ...any) {
+	if p.game == nil {
+		return
+	}
+	p.game.log().Error(msg, append([]any{"player", p.name, "error", err}, args...)...)
+}
+
+// hasEnoughChips returns true if the player can afford the amount, or the error
+// reported by the chip manager
+func (p *Player) hasEnoughChips(ctx context.Context, amount int) (bool, error) {
+	if p.contextChips == nil && p.chipManager == nil {
+		return false, nil
+	}
+	ctx, cancel := p.chipContext(ctx)
+	defer cancel()
+	return p.wallet().HasEnoughChips(ctx, amount)
+}
@@ -0,0 +1,58 @@
+package blackjack
+
+import "context"
+
+// ChipReason describes why a player's chip balance changed
+type ChipReason string
+
+const (
+	ChipReasonBet        ChipReason = "bet"         // ChipReasonBet is a bet placed on a hand
+	ChipReasonDoubleDown ChipReason = "double_down" // ChipReasonDoubleDown is the additional bet for a double down
+	ChipReasonSplit      ChipReason = "split"       // ChipReasonSplit is the bet placed on a new split hand
+	ChipReasonSurrender  ChipReason = "surrender"   // ChipReasonSurrender is half a bet returned on surrender
+	ChipReasonPayout     ChipReason = "payout"      // ChipReasonPayout is a settled hand being paid
+	ChipReasonRebuy      ChipReason = "rebuy"       // ChipReasonRebuy is chips added to the player's account
+	ChipReasonReset      ChipReason = "reset"       // ChipReasonReset is the balance being restored for a new session
+	ChipReasonRefund     ChipReason = "refund"      // ChipReasonRefund is an unsettled bet returned when a round is abandoned
+	ChipReasonBonus      ChipReason = "bonus"       // ChipReasonBonus is free chips claimed by the player
+)
+
+// creditChips adds chips to the player's account and reports the change
+func (p *Player) creditChips(ctx context.Context, amount int, reason ChipReason) error {
+	p.labelChips(reason)
+	ctx, cancel := p.chipContext(ctx)
+	defer cancel()
+	if err := p.wallet().AddChips(ctx, amount); err != nil {
+		return err
+	}
+	p.chipsChanged(amount, reason)
+	return nil
+}
+
+// debitChips removes chips from the player's account and reports the change
+func (p *Player) debitChips(ctx context.Context, amount int, reason ChipReason) error {
+	p.labelChips(reason)
+	ctx, cancel := p.chipContext(ctx)
+	defer cancel()
+	if err := p.wallet().DeductChips(ctx, amount); err != nil {
+		return err
+	}
+	p.chipsChanged(-amount, reason)
+	return nil
+}
+
+// chipsChanged emits a chip change event if the player is seated in a game
+func (p *Player) chipsChanged(amount int, reason ChipReason) {
+	if p.game == nil || amount == 0 {
+		return
+	}
+	p.game.log().Debug("chips changed", "player", p.name, "amount", amount, "balance", p.Chips(), "reason", reason)
+	p.game.emit(Event{
+		Type:    EventChipsChanged,
+		Player:  p.name,
+		Hand:    p.currentHandIdx,
+		Amount:  amount,
+		Balance: p.Chips(),
+		Reason:  reason,
+	})
+}
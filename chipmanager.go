@@ -13,7 +13,8 @@ type ChipManager interface {
 
 // DefaultChipManager implements ChipManager with simple integer-based chip management
 type DefaultChipManager struct {
-	chips int
+	chips    int
+	reserved int // reserved is the amount held in escrow for unsettled bets
 }
 
 // NewDefaultChipManager creates a new default chip manager with the given initial amount
@@ -0,0 +1,211 @@
+package blackjack
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Denomination is a chip value along with the color used to display it
+type Denomination struct {
+	Value int    `json:"value"` // Value is the number of chips (or minor currency units) the chip is worth
+	Color string `json:"color"` // Color is the color of the chip, for display
+}
+
+// DefaultDenominations are the standard casino chip denominations
+var DefaultDenominations = []Denomination{
+	{Value: 1, Color: "white"},
+	{Value: 5, Color: "red"},
+	{Value: 25, Color: "green"},
+	{Value: 100, Color: "black"},
+	{Value: 500, Color: "purple"},
+	{Value: 1000, Color: "orange"},
+}
+
+// ChipStack is a pile of physical chips, mapping each denomination's value to the
+// number of chips of that denomination
+type ChipStack map[int]int
+
+// MakeChange returns the stack with the fewest chips worth the amount, using the
+// given denominations (DefaultDenominations if none are given). The largest chips
+// are used first, which gives the fewest chips for standard denominations.
+func MakeChange(amount int, denominations ...Denomination) (ChipStack, error) {
+	if amount < 0 {
+		return nil, fmt.Errorf("cannot make change for a negative amount of %d", amount)
+	}
+	values := denominationValues(denominations)
+	stack := ChipStack{}
+	remaining := amount
+	for _, value := range values {
+		if count := remaining / value; count > 0 {
+			stack[value] = count
+			remaining -= count * value
+		}
+	}
+	if remaining != 0 {
+		return nil, fmt.Errorf("cannot make change for %d with denominations %v", amount, values)
+	}
+	return stack, nil
+}
+
+// ChipStack returns the player's chips as a stack with the fewest chips, using the
+// given denominations (DefaultDenominations if none are given)
+func (p *Player) ChipStack(denominations ...Denomination) (ChipStack, error) {
+	return MakeChange(p.Chips(), denominations...)
+}
+
+// Total returns the total value of the stack
+func (s ChipStack) Total() int {
+	total := 0
+	for value, count := range s {
+		total += value * count
+	}
+	return total
+}
+
+// Count returns the number of chips in the stack
+func (s ChipStack) Count() int {
+	count := 0
+	for _, n := range s {
+		count += n
+	}
+	return count
+}
+
+// Add returns a new stack with the chips from both stacks
+func (s ChipStack) Add(other ChipStack) ChipStack {
+	result := maps.Clone(s)
+	if result == nil {
+		result = ChipStack{}
+	}
+	for value, count := range other {
+		result[value] += count
+	}
+	return result
+}
+
+// Remove returns a new stack without the given chips. An error is returned if the
+// stack does not hold all of them.
+func (s ChipStack) Remove(other ChipStack) (ChipStack, error) {
+	result := maps.Clone(s)
+	if result == nil {
+		result = ChipStack{}
+	}
+	for value, count := range other {
+		if count <= 0 {
+			continue
+		}
+		if result[value] < count {
+			return nil, fmt.Errorf("stack has %d chips of %d, need %d", result[value], value, count)
+		}
+		result[value] -= count
+		if result[value] == 0 {
+			delete(result, value)
+		}
+	}
+	return result, nil
+}
+
+// ColorUp returns a stack of the same value exchanged for the fewest, largest chips
+func (s ChipStack) ColorUp(denominations ...Denomination) (ChipStack, error) {
+	return MakeChange(s.Total(), denominations...)
+}
+
+// Break returns a new stack with one chip of the given value exchanged for smaller
+// chips, using the given denominations (DefaultDenominations if none are given)
+func (s ChipStack) Break(value int, denominations ...Denomination) (ChipStack, error) {
+	if s[value] == 0 {
+		return nil, fmt.Errorf("stack has no chips of %d to break", value)
+	}
+	var smaller []Denomination
+	for _, v := range denominationValues(denominations) {
+		if v < value {
+			smaller = append(smaller, Denomination{Value: v})
+		}
+	}
+	if len(smaller) == 0 {
+		return nil, fmt.Errorf("no denominations smaller than %d", value)
+	}
+	change, err := MakeChange(value, smaller...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.Remove(ChipStack{value: 1})
+	if err != nil {
+		return nil, err
+	}
+	return result.Add(change), nil
+}
+
+// Values returns the denominations in the stack, largest first
+func (s ChipStack) Values() []int {
+	values := make([]int, 0, len(s))
+	for value, count := range s {
+		if count > 0 {
+			values = append(values, value)
+		}
+	}
+	slices.Sort(values)
+	slices.Reverse(values)
+	return values
+}
+
+// String returns the stack's chips, largest first (e.g., "2×100 1×25 3×1")
+func (s ChipStack) String() string {
+	return s.Format(PlainChips)
+}
+
+// Format returns the stack's chips, largest first, with each denomination written
+// in the currency (e.g., "2×1.00 USD 1×0.25 USD")
+func (s ChipStack) Format(currency Currency) string {
+	if s.Count() == 0 {
+		return "no chips"
+	}
+	parts := make([]string, 0, len(s))
+	for _, value := range s.Values() {
+		parts = append(parts, fmt.Sprintf("%d×%s", s[value], currency.Format(value)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Describe returns the stack's chips, largest first, with the color of each
+// denomination (e.g., "2 black (100), 1 green (25)")
+func (s ChipStack) Describe(denominations ...Denomination) string {
+	if s.Count() == 0 {
+		return "no chips"
+	}
+	if len(denominations) == 0 {
+		denominations = DefaultDenominations
+	}
+	colors := make(map[int]string, len(denominations))
+	for _, d := range denominations {
+		colors[d.Value] = d.Color
+	}
+	parts := make([]string, 0, len(s))
+	for _, value := range s.Values() {
+		color := colors[value]
+		if color == "" {
+			color = "chips"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s (%d)", s[value], color, value))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// denominationValues returns the positive values of the denominations, largest
+// first, or the values of DefaultDenominations if none are given
+func denominationValues(denominations []Denomination) []int {
+	if len(denominations) == 0 {
+		denominations = DefaultDenominations
+	}
+	values := make([]int, 0, len(denominations))
+	for _, d := range denominations {
+		if d.Value > 0 && !slices.Contains(values, d.Value) {
+			values = append(values, d.Value)
+		}
+	}
+	slices.Sort(values)
+	slices.Reverse(values)
+	return values
+}
@@ -0,0 +1,260 @@
+// Package client is a Go client for the blackjack REST and WebSocket API served
+// by package server/http.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/rbrabson/blackjack"
+	serverhttp "github.com/rbrabson/blackjack/server/http"
+)
+
+// Client makes requests to a blackjack server. The client remembers the seat token
+// issued to each player it joins to a table, and uses it to act for the player.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	mu         sync.Mutex
+	tokens     map[seat]string // tokens are the seat tokens of the players the client acts for
+}
+
+// seat identifies a player at a table
+type seat struct {
+	table  string
+	player string
+}
+
+// Option is a function that configures a client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to make requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// New creates a client for the server at baseURL, such as "http://localhost:8080"
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		tokens:     make(map[seat]string),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// CreateTable creates a new table with the given number of decks (0 for the
+// server's default) and rules (nil for the default rules)
+func (c *Client) CreateTable(ctx context.Context, decks int, rules *blackjack.Rules) (*blackjack.GameState, error) {
+	var state blackjack.GameState
+	req := serverhttp.CreateTableRequest{Decks: decks, Rules: rules}
+	if err := c.do(ctx, http.MethodPost, "/tables", "", "", req, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// ListTables returns the IDs of every table on the server
+func (c *Client) ListTables(ctx context.Context) ([]string, error) {
+	var ids []string
+	if err := c.do(ctx, http.MethodGet, "/tables", "", "", nil, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// State returns the redacted state of a table
+func (c *Client) State(ctx context.Context, table string) (*blackjack.GameState, error) {
+	var state blackjack.GameState
+	if err := c.do(ctx, http.MethodGet, tablePath(table), "", "", nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// CloseTable closes a table
+func (c *Client) CloseTable(ctx context.Context, table string) error {
+	return c.do(ctx, http.MethodDelete, tablePath(table), "", "", nil, nil)
+}
+
+// JoinTable seats a player at a table with the given bankroll (0 for the server's
+// default). The client remembers the player's seat token to act for them.
+func (c *Client) JoinTable(ctx context.Context, table string, name string, chips int) (*blackjack.PlayerState, error) {
+	var resp serverhttp.JoinResponse
+	req := serverhttp.JoinRequest{Name: name, Chips: chips}
+	if err := c.do(ctx, http.MethodPost, tablePath(table)+"/players", "", "", req, &resp); err != nil {
+		return nil, err
+	}
+	c.SetToken(table, name, resp.Token)
+	return &resp.Player, nil
+}
+
+// LeaveTable removes a player from a table
+func (c *Client) LeaveTable(ctx context.Context, table string, name string) error {
+	if err := c.do(ctx, http.MethodDelete, tablePath(table)+"/players/"+url.PathEscape(name), "", c.Token(table, name), nil, nil); err != nil {
+		return err
+	}
+	c.SetToken(table, name, "")
+	return nil
+}
+
+// StartRound starts a new round at a table on behalf of a seated player
+func (c *Client) StartRound(ctx context.Context, table string, player string) (*blackjack.GameState, error) {
+	var state blackjack.GameState
+	if err := c.do(ctx, http.MethodPost, tablePath(table)+"/rounds", "", c.Token(table, player), nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// PlaceBet places a bet on one of a player's spots
+func (c *Client) PlaceBet(ctx context.Context, table string, player string, spot int, amount int) (*blackjack.GameState, error) {
+	return c.PlaceBetWithKey(ctx, "", table, player, spot, amount)
+}
+
+// PlaceBetWithKey places a bet like PlaceBet with an idempotency key, so that the
+// request may be safely retried
+func (c *Client) PlaceBetWithKey(ctx context.Context, key string, table string, player string, spot int, amount int) (*blackjack.GameState, error) {
+	var state blackjack.GameState
+	req := serverhttp.BetRequest{Player: player, Spot: spot, Amount: amount}
+	if err := c.do(ctx, http.MethodPost, tablePath(table)+"/bets", key, c.Token(table, player), req, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Deal deals the initial cards at a table once bets have been placed, on behalf of
+// a seated player
+func (c *Client) Deal(ctx context.Context, table string, player string) (*blackjack.GameState, error) {
+	var state blackjack.GameState
+	if err := c.do(ctx, http.MethodPost, tablePath(table)+"/deal", "", c.Token(table, player), nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Act performs an action on a player's current hand
+func (c *Client) Act(ctx context.Context, table string, player string, action blackjack.ActionType) (*serverhttp.ActionResponse, error) {
+	return c.ActWithKey(ctx, "", table, player, action)
+}
+
+// ActWithKey performs an action like Act with an idempotency key, so that the
+// request may be safely retried
+func (c *Client) ActWithKey(ctx context.Context, key string, table string, player string, action blackjack.ActionType) (*serverhttp.ActionResponse, error) {
+	var resp serverhttp.ActionResponse
+	req := serverhttp.ActionRequest{Player: player, Action: action}
+	if err := c.do(ctx, http.MethodPost, tablePath(table)+"/actions", key, c.Token(table, player), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Hit deals a card to a player's current hand
+func (c *Client) Hit(ctx context.Context, table string, player string) (*serverhttp.ActionResponse, error) {
+	return c.Act(ctx, table, player, blackjack.ActionHit)
+}
+
+// Stand stands on a player's current hand
+func (c *Client) Stand(ctx context.Context, table string, player string) (*serverhttp.ActionResponse, error) {
+	return c.Act(ctx, table, player, blackjack.ActionStand)
+}
+
+// DoubleDown doubles down on a player's current hand
+func (c *Client) DoubleDown(ctx context.Context, table string, player string) (*serverhttp.ActionResponse, error) {
+	return c.Act(ctx, table, player, blackjack.ActionDouble)
+}
+
+// Split splits a player's current hand
+func (c *Client) Split(ctx context.Context, table string, player string) (*serverhttp.ActionResponse, error) {
+	return c.Act(ctx, table, player, blackjack.ActionSplit)
+}
+
+// Surrender surrenders a player's current hand
+func (c *Client) Surrender(ctx context.Context, table string, player string) (*serverhttp.ActionResponse, error) {
+	return c.Act(ctx, table, player, blackjack.ActionSurrender)
+}
+
+// SetToken sets the seat token the client uses to act for a player at a table, such
+// as a token saved from an earlier session
+func (c *Client) SetToken(table string, player string, token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if token == "" {
+		delete(c.tokens, seat{table, player})
+		return
+	}
+	c.tokens[seat{table, player}] = token
+}
+
+// Token returns the seat token the client uses to act for a player at a table, or
+// an empty string if the client has none
+func (c *Client) Token(table string, player string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens[seat{table, player}]
+}
+
+// tablePath returns the API path of a table
+func tablePath(table string) string {
+	return "/tables/" + url.PathEscape(table)
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into
+// out. The request carries the idempotency key and seat token if they are not
+// empty. Errors returned by the server are returned as *serverhttp.Error.
+func (c *Client) do(ctx context.Context, method string, path string, key string, token string, in any, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &serverhttp.Error{}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil || apiErr.Code == "" {
+			return fmt.Errorf("%s %s failed: %s", method, path, resp.Status)
+		}
+		return apiErr
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
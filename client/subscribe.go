@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/rbrabson/blackjack/server/ws"
+)
+
+// Subscription is a WebSocket connection to a table that receives the table's
+// events and state as they change
+type Subscription struct {
+	client   *Client
+	table    string
+	player   string
+	conn     *websocket.Conn
+	messages chan ws.Message
+	mu       sync.Mutex // mu serializes writes to the connection
+	err      error
+}
+
+// Subscribe connects to a table's event stream. If player is not empty, commands
+// sent on the subscription act on behalf of that player, using the seat token the
+// client has for the player if they are already seated; otherwise the subscription
+// only watches the table. The token issued when the player joins over the
+// subscription is remembered by the client.
+func (c *Client) Subscribe(ctx context.Context, table string, player string) (*Subscription, error) {
+	u, err := url.Parse(c.baseURL + tablePath(table) + "/ws")
+	if err != nil {
+		return nil, err
+	}
+	u.Scheme = strings.Replace(u.Scheme, "http", "ws", 1)
+	if player != "" {
+		query := url.Values{"player": {player}}
+		if token := c.Token(table, player); token != "" {
+			query.Set("token", token)
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, u.String(), nil)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			// The table refused the connection, such as for a missing seat token
+			return nil, fmt.Errorf("failed to subscribe to table %s: %s", table, resp.Status)
+		}
+		return nil, fmt.Errorf("failed to subscribe to table %s: %w", table, err)
+	}
+	s := &Subscription{
+		client:   c,
+		table:    table,
+		player:   player,
+		conn:     conn,
+		messages: make(chan ws.Message, 64),
+	}
+	go s.readLoop()
+	return s, nil
+}
+
+// Messages returns the channel that receives the table's messages. The channel is
+// closed when the subscription ends; Err reports why.
+func (s *Subscription) Messages() <-chan ws.Message {
+	return s.messages
+}
+
+// Err returns the error that ended the subscription, if any
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Send sends a command to the table
+func (s *Subscription) Send(cmd ws.Command) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WriteJSON(cmd)
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	return s.conn.Close()
+}
+
+// readLoop delivers messages from the table until the connection is closed
+func (s *Subscription) readLoop() {
+	defer close(s.messages)
+	for {
+		var msg ws.Message
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				s.err = err
+			}
+			return
+		}
+		if msg.Type == ws.MessageJoined {
+			s.client.SetToken(s.table, s.player, msg.Token)
+		}
+		s.messages <- msg
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/strategy"
+)
+
+const (
+	botChips = 1000 // botChips is the number of chips each computer player starts with
+	botBet   = 10   // botBet is what a computer player bets, kept within the table limits
+)
+
+// seatBots adds computer players who bet and play by the named strategy
+func seatBots(game *blackjack.Game, count int, strategyName string) {
+	botStrategy, _ := strategy.Lookup(strategyName) // validated by parseConfig
+	for i, added := 1, 0; added < count; i++ {
+		name := fmt.Sprintf("Bot %d", i)
+		if game.GetPlayer(name) != nil {
+			continue
+		}
+		game.AddPlayer(name, blackjack.WithChips(botChips))
+		player := game.GetPlayer(name)
+		player.SetSeat(strategy.NewSeat(botStrategy, botBet))
+		player.SetMetadata(botStrategyKey, strategyName)
+		added++
+	}
+}
+
+// isBot returns whether the player is a computer player
+func isBot(player *blackjack.Player) bool {
+	return player.Seat() != nil
+}
+
+// placeBotBet asks a computer player's seat for a bet and places it. It returns 0
+// if the player sits the round out.
+func placeBotBet(game *blackjack.Game, player *blackjack.Player) (int, error) {
+	decision := blackjack.BetDecision{
+		Player: player.Name(),
+		Chips:  player.Chips(),
+		Rules:  game.Rules(),
+		State:  game.State(),
+	}
+	amount, err := player.Seat().Bet(context.Background(), decision)
+	if err != nil || amount == 0 {
+		return 0, err
+	}
+	return amount, game.PlaceBet(player.Name(), 0, amount)
+}
+
+// playBotAction asks a computer player's seat for an action on their current hand
+// and takes it
+func playBotAction(game *blackjack.Game, player *blackjack.Player) (blackjack.ActionType, error) {
+	decision := blackjack.ActionDecision{
+		Player:  player.Name(),
+		Hand:    player.GetCurrentHandNumber(),
+		Actions: player.AvailableActions(),
+		Rules:   game.Rules(),
+		State:   game.State(),
+	}
+	action, err := player.Seat().Act(context.Background(), decision)
+	if err != nil {
+		return "", err
+	}
+	_, err = game.Act(player.Name(), action)
+	return action, err
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/cards"
+)
+
+const (
+	hiddenCard = "🂠" // hiddenCard is shown in place of the dealer's hole card
+)
+
+var (
+	redSuitStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	blackSuitStyle   = lipgloss.NewStyle().Bold(true)
+	bustStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	blackjackStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
+	currentHandStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+)
+
+// disableColor turns off colored output, leaving the card glyphs in plain text
+func disableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// rankLabel returns the short label for a card's rank
+func rankLabel(rank cards.Rank) string {
+	switch rank {
+	case cards.Ace:
+		return "A"
+	case cards.Jack:
+		return "J"
+	case cards.Queen:
+		return "Q"
+	case cards.King:
+		return "K"
+	default:
+		return strconv.Itoa(int(rank))
+	}
+}
+
+// suitSymbol returns the symbol for a card's suit
+func suitSymbol(suit cards.Suit) string {
+	switch suit {
+	case cards.Hearts:
+		return "♥"
+	case cards.Diamonds:
+		return "♦"
+	case cards.Clubs:
+		return "♣"
+	default:
+		return "♠"
+	}
+}
+
+// isRed returns whether the card is a heart or a diamond
+func isRed(card cards.Card) bool {
+	return card.Suit == cards.Hearts || card.Suit == cards.Diamonds
+}
+
+// cardLabel returns the rank and suit symbol of a card, such as A♠
+func cardLabel(card cards.Card) string {
+	return rankLabel(card.Rank) + suitSymbol(card.Suit)
+}
+
+// cardGlyph returns the label of a card, colored by its suit
+func cardGlyph(card cards.Card) string {
+	if isRed(card) {
+		return redSuitStyle.Render(cardLabel(card))
+	}
+	return blackSuitStyle.Render(cardLabel(card))
+}
+
+// handText returns a hand's cards and value, such as "A♠ 7♥ (soft 18)"
+func handText(hand blackjack.HandState) string {
+	glyphs := make([]string, 0, len(hand.Cards))
+	for _, card := range hand.Cards {
+		glyphs = append(glyphs, cardGlyph(card))
+	}
+
+	var value string
+	switch {
+	case len(hand.Cards) == 0:
+		return "(no cards)"
+	case hand.Blackjack:
+		value = blackjackStyle.Render("Blackjack")
+	case hand.Busted:
+		value = bustStyle.Render(fmt.Sprintf("%d, bust", hand.Value))
+	case hand.Soft:
+		value = fmt.Sprintf("soft %d", hand.Value)
+	default:
+		value = strconv.Itoa(hand.Value)
+	}
+	return fmt.Sprintf("%s (%s)", strings.Join(glyphs, " "), value)
+}
+
+// dealerText returns the dealer's cards and the value of those showing
+func dealerText(dealer blackjack.DealerState) string {
+	glyphs := make([]string, 0, len(dealer.Cards))
+	for _, card := range dealer.Cards {
+		if card == nil {
+			glyphs = append(glyphs, hiddenCard)
+			continue
+		}
+		glyphs = append(glyphs, cardGlyph(*card))
+	}
+	if len(glyphs) == 0 {
+		return "(no cards)"
+	}
+	return fmt.Sprintf("%s (%d)", strings.Join(glyphs, " "), dealer.Value)
+}
+
+// showTable prints the dealer's and players' hands, highlighting the hand being
+// played
+func showTable(game *blackjack.Game) {
+	active := ""
+	if player := game.GetActivePlayer(); player != nil {
+		active = player.Name()
+	}
+	printTable(game.State(), active)
+}
+
+// printTable prints the dealer's and players' hands from the table's state,
+// highlighting the current hand of the named active player
+func printTable(state blackjack.GameState, active string) {
+	fmt.Printf("Dealer: %s\n", dealerText(state.Dealer))
+	for _, player := range state.Players {
+		fmt.Printf("%s (Chips: %d)\n", player.Name, player.Chips)
+		for i, hand := range player.Hands {
+			if hand.Bet == 0 && len(hand.Cards) == 0 {
+				continue
+			}
+			line := fmt.Sprintf("  Hand %d: %s, bet %d", hand.ID, handText(hand), hand.Bet)
+			if player.Name == active && i == player.CurrentHand {
+				line = currentHandStyle.Render("▶ " + strings.TrimPrefix(line, "  "))
+			}
+			fmt.Println(line)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/strategy"
+	"gopkg.in/yaml.v3"
+)
+
+// tableConfig describes the table and, optionally, the players for a session. It
+// may be loaded from a YAML file with -config, such as:
+//
+//	decks: 6
+//	penetration: 0.75
+//	h17: false
+//	payout: "6:5"
+//	min_bet: 10
+//	max_bet: 500
+//	bots: 2
+//	bot_strategy: basic
+//	players:
+//	  - name: Alice
+//	    chips: 1000
+type tableConfig struct {
+	Decks        int            `yaml:"decks"`          // Decks is the number of decks in the shoe
+	Penetration  float64        `yaml:"penetration"`    // Penetration is the fraction of the shoe dealt before it is reshuffled
+	Seed         uint64         `yaml:"seed"`           // Seed seeds the shuffle (0 for a random shuffle)
+	Burn         int            `yaml:"burn"`           // Burn is the number of cards burned face up after each shuffle
+	FinishShoe   bool           `yaml:"finish_shoe"`    // FinishShoe deals past the cut card, shuffling only when too few cards are left for a round
+	H17          bool           `yaml:"h17"`            // H17 is whether the dealer hits soft 17
+	Payout       string         `yaml:"payout"`         // Payout is the blackjack payout ratio
+	MinBet       int            `yaml:"min_bet"`        // MinBet is the smallest bet allowed (0 for no minimum)
+	MaxBet       int            `yaml:"max_bet"`        // MaxBet is the largest bet allowed (0 for no maximum)
+	Charlie      int            `yaml:"charlie"`        // Charlie is the number of cards that wins a hand without busting (0 for no Charlie)
+	DAS          bool           `yaml:"das"`            // DAS is whether doubling down is allowed after a split
+	Surrender    bool           `yaml:"surrender"`      // Surrender is whether surrendering is allowed
+	SplitHands   int            `yaml:"split_hands"`    // SplitHands is the most hands a spot may be split into
+	ResplitAces  bool           `yaml:"resplit_aces"`   // ResplitAces is whether split aces may be split again
+	HitSplitAces bool           `yaml:"hit_split_aces"` // HitSplitAces is whether split aces may take more than one card
+	Players      []playerConfig `yaml:"players"`        // Players are seated without prompting, if any are given
+	TUI          bool           `yaml:"tui"`            // TUI is whether to play in the full-screen terminal UI
+	NoColor      bool           `yaml:"no_color"`       // NoColor turns off colored output
+	Count        string         `yaml:"count"`          // Count shows or quizzes the card count for counting practice
+	QuitPolicy   string         `yaml:"quit_policy"`    // QuitPolicy is how a round in progress is finished when the players quit
+
+	Bots        int    `yaml:"bots"`         // Bots is the number of computer players seated alongside the players
+	BotStrategy string `yaml:"bot_strategy"` // BotStrategy is the strategy the computer players play by
+
+	Resume string `yaml:"-"` // Resume is a file saved by a previous session to continue, instead of starting a new game
+
+	askTable bool // askTable is whether to ask for the shoe and rules, as neither flags nor a file describe them
+}
+
+// playerConfig describes a player seated at the start of the session
+type playerConfig struct {
+	Name  string `yaml:"name"`
+	Chips int    `yaml:"chips"`
+}
+
+// defaultConfig returns the table used when no configuration is given
+func defaultConfig() tableConfig {
+	return tableConfig{Decks: 6, Penetration: blackjack.CutCardPenetration, H17: true, Payout: "3:2", DAS: true, Surrender: true, SplitHands: blackjack.MaxHandsPerSpot, QuitPolicy: quitStand, BotStrategy: "basic"}
+}
+
+// parseConfig reads the configuration from the command line flags and, if -config
+// is given, the configuration file. Flags given on the command line take
+// precedence over the file. The flags are defined on fs and parsed from args.
+func parseConfig(fs *flag.FlagSet, args []string) (tableConfig, error) {
+	cfg := defaultConfig()
+	path := fs.String("config", "", "YAML file describing the table rules and players")
+	fs.IntVar(&cfg.Decks, "decks", cfg.Decks, "number of decks in the shoe")
+	fs.Float64Var(&cfg.Penetration, "penetration", cfg.Penetration, "fraction of the shoe dealt before it is reshuffled")
+	fs.Uint64Var(&cfg.Seed, "seed", cfg.Seed, "seed for shuffling the shoe, to deal reproducible cards (0 for a random shuffle)")
+	fs.IntVar(&cfg.Burn, "burn", cfg.Burn, "number of cards burned face up after each shuffle")
+	fs.BoolVar(&cfg.FinishShoe, "finish-shoe", cfg.FinishShoe, "deal past the cut card, shuffling only when too few cards are left to finish a round")
+	fs.BoolVar(&cfg.H17, "h17", cfg.H17, "dealer hits soft 17 (use -h17=false for the dealer to stand)")
+	fs.StringVar(&cfg.Payout, "payout", cfg.Payout, "blackjack payout ratio, such as 3:2 or 6:5")
+	fs.IntVar(&cfg.MinBet, "min-bet", cfg.MinBet, "smallest bet allowed (0 for no minimum)")
+	fs.IntVar(&cfg.MaxBet, "max-bet", cfg.MaxBet, "largest bet allowed (0 for no maximum)")
+	fs.BoolVar(&cfg.DAS, "das", cfg.DAS, "doubling down is allowed after a split")
+	fs.BoolVar(&cfg.Surrender, "surrender", cfg.Surrender, "surrendering is allowed")
+	fs.IntVar(&cfg.SplitHands, "split-hands", cfg.SplitHands, fmt.Sprintf("most hands a spot may be split into, from 2 (no resplitting) to %d", blackjack.MaxHandsPerSpot))
+	fs.BoolVar(&cfg.ResplitAces, "resplit-aces", cfg.ResplitAces, "a split ace dealt another ace may be split again")
+	fs.BoolVar(&cfg.HitSplitAces, "hit-split-aces", cfg.HitSplitAces, "split aces may be hit and doubled down like any other hand, instead of receiving one card each")
+	fs.IntVar(&cfg.Charlie, "charlie", cfg.Charlie, "number of cards that wins a hand without busting, such as 5 for a five-card Charlie (0 for no Charlie)")
+	fs.BoolVar(&cfg.TUI, "tui", cfg.TUI, "play in a full-screen terminal UI instead of the line-based prompts")
+	fs.BoolVar(&cfg.NoColor, "no-color", cfg.NoColor, "print plain text without colors")
+	fs.StringVar(&cfg.Count, "count", cfg.Count, "practice card counting: show the count after each card, or quiz it after each round (show or quiz)")
+	fs.StringVar(&cfg.QuitPolicy, "quit-policy", cfg.QuitPolicy, "how a dealt round is finished when the players quit: stand the remaining hands and settle it, or refund its bets (stand or refund)")
+	fs.IntVar(&cfg.Bots, "bots", cfg.Bots, "number of computer players to seat alongside the players")
+	fs.StringVar(&cfg.Resume, "resume", "", "continue the session saved to a file with the save command")
+	fs.StringVar(&cfg.BotStrategy, "bot-strategy", cfg.BotStrategy, fmt.Sprintf("strategy the computer players play by (%s)", strings.Join(strategy.Names(), " or ")))
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	cfg.askTable = *path == ""
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "decks", "penetration", "h17":
+			cfg.askTable = false
+		}
+	})
+
+	if *path != "" {
+		file := defaultConfig()
+		if err := loadConfig(*path, &file); err != nil {
+			return cfg, err
+		}
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "decks":
+				file.Decks = cfg.Decks
+			case "penetration":
+				file.Penetration = cfg.Penetration
+			case "seed":
+				file.Seed = cfg.Seed
+			case "burn":
+				file.Burn = cfg.Burn
+			case "finish-shoe":
+				file.FinishShoe = cfg.FinishShoe
+			case "h17":
+				file.H17 = cfg.H17
+			case "payout":
+				file.Payout = cfg.Payout
+			case "min-bet":
+				file.MinBet = cfg.MinBet
+			case "max-bet":
+				file.MaxBet = cfg.MaxBet
+			case "charlie":
+				file.Charlie = cfg.Charlie
+			case "das":
+				file.DAS = cfg.DAS
+			case "surrender":
+				file.Surrender = cfg.Surrender
+			case "split-hands":
+				file.SplitHands = cfg.SplitHands
+			case "resplit-aces":
+				file.ResplitAces = cfg.ResplitAces
+			case "hit-split-aces":
+				file.HitSplitAces = cfg.HitSplitAces
+			case "tui":
+				file.TUI = cfg.TUI
+			case "no-color":
+				file.NoColor = cfg.NoColor
+			case "count":
+				file.Count = cfg.Count
+			case "quit-policy":
+				file.QuitPolicy = cfg.QuitPolicy
+			case "bots":
+				file.Bots = cfg.Bots
+			case "bot-strategy":
+				file.BotStrategy = cfg.BotStrategy
+			case "resume":
+				file.Resume = cfg.Resume
+			}
+		})
+		cfg = file
+	}
+
+	return cfg, cfg.validate()
+}
+
+// loadConfig reads a YAML configuration file over the given configuration
+func loadConfig(path string, cfg *tableConfig) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+	return nil
+}
+
+// validate returns an error if the configuration does not describe a valid table
+func (c tableConfig) validate() error {
+	if c.Decks <= 0 {
+		return fmt.Errorf("decks must be positive")
+	}
+	if c.Penetration <= 0 || c.Penetration > 1 {
+		return fmt.Errorf("penetration must be more than 0 and at most 1")
+	}
+	if c.Burn < 0 {
+		return fmt.Errorf("burn must not be negative")
+	}
+	if c.MinBet < 0 || c.MaxBet < 0 || (c.MaxBet > 0 && c.MaxBet < c.MinBet) {
+		return fmt.Errorf("bet limits must not be negative, and the maximum bet must be at least the minimum bet")
+	}
+	if _, err := blackjack.ParsePayoutRatio(c.Payout); err != nil {
+		return err
+	}
+	if c.SplitHands < 2 || c.SplitHands > blackjack.MaxHandsPerSpot {
+		return fmt.Errorf("split hands must be from 2 to %d", blackjack.MaxHandsPerSpot)
+	}
+	if c.Charlie != 0 && c.Charlie < 3 {
+		return fmt.Errorf("charlie must be at least 3 cards, or 0 for no Charlie")
+	}
+	if c.Count != "" && c.Count != countShow && c.Count != countQuiz {
+		return fmt.Errorf("count must be %s or %s", countShow, countQuiz)
+	}
+	if c.Count != "" && c.TUI {
+		return fmt.Errorf("count practice is not available in the TUI")
+	}
+	if c.QuitPolicy != quitStand && c.QuitPolicy != quitRefund {
+		return fmt.Errorf("quit policy must be %s or %s", quitStand, quitRefund)
+	}
+	if c.Bots < 0 {
+		return fmt.Errorf("bots must not be negative")
+	}
+	if _, err := strategy.Lookup(c.BotStrategy); err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(c.Players))
+	for _, player := range c.Players {
+		switch {
+		case player.Name == "":
+			return fmt.Errorf("every player must have a name")
+		case names[player.Name]:
+			return fmt.Errorf("player %s is listed more than once", player.Name)
+		case player.Chips <= 0:
+			return fmt.Errorf("player %s must start with a positive number of chips", player.Name)
+		}
+		names[player.Name] = true
+	}
+	return nil
+}
+
+// rules returns the table rules described by the configuration
+func (c tableConfig) rules() blackjack.Rules {
+	rules := blackjack.DefaultRules()
+	rules.StandSoft17 = !c.H17
+	rules.BlackjackPayout, _ = blackjack.ParsePayoutRatio(c.Payout) // validated by parseConfig
+	rules.MinBet = c.MinBet
+	rules.MaxBet = c.MaxBet
+	rules.CharlieCards = c.Charlie
+	rules.NoDoubleAfterSplit = !c.DAS
+	rules.NoSurrender = !c.Surrender
+	rules.MaxSplitHands = c.SplitHands
+	rules.ResplitAces = c.ResplitAces
+	rules.HitSplitAces = c.HitSplitAces
+	return rules
+}
+
+// newGame returns the game described by the configuration, or the saved game if
+// -resume is given. A resumed game keeps the table rules, shoe, and players it was
+// saved with.
+func (c tableConfig) newGame(logger *slog.Logger) (*blackjack.Game, error) {
+	if c.Resume != "" {
+		return resumeGame(c.Resume, blackjack.WithLogger(logger), blackjack.WithBurnCards(c.Burn))
+	}
+	options := []blackjack.GameOption{blackjack.WithLogger(logger), blackjack.WithRules(c.rules()), blackjack.WithPenetration(c.Penetration), blackjack.WithBurnCards(c.Burn)}
+	if c.Seed != 0 {
+		options = append(options, blackjack.WithSeed(c.Seed))
+	}
+	if c.FinishShoe {
+		options = append(options, blackjack.WithShufflePolicy(blackjack.ShuffleFinishShoe))
+	}
+	return blackjack.New(c.Decks, options...), nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/count"
+	"github.com/rbrabson/cards"
+)
+
+const (
+	countShow = "show" // countShow shows the count after each card is dealt
+	countQuiz = "quiz" // countQuiz asks for the running count after each round
+)
+
+// countPractice shows or quizzes the Hi-Lo count while the game is played, for
+// players practicing card counting
+type countPractice struct {
+	counter  *count.Counter
+	quiz     bool // quiz is whether the count is asked for rather than shown
+	answered int  // answered is the number of quiz questions answered
+	correct  int  // correct is the number of quiz questions answered correctly
+}
+
+// startCountPractice starts counting the cards dealt in the game, in the given
+// practice mode. It returns nil if the count isn't being practiced.
+func startCountPractice(game *blackjack.Game, mode string) *countPractice {
+	if mode == "" {
+		return nil
+	}
+	p := &countPractice{counter: count.NewCounter(game.Shoe()), quiz: mode == countQuiz}
+	game.AddListener(p)
+	fmt.Println("🔢 Counting practice: 2-6 count +1, 7-9 count 0, and tens and aces count -1.")
+	return p
+}
+
+// OnEvent counts the card dealt, showing the new count unless the player is being
+// quizzed
+func (p *countPractice) OnEvent(event blackjack.Event) {
+	p.counter.OnEvent(event)
+	switch event.Type {
+	case blackjack.EventShoeShuffled:
+		fmt.Println("🔀 The shoe was reshuffled, so the count starts over at 0.")
+	case blackjack.EventCardDealt:
+		if event.Card != nil && !p.quiz {
+			p.show(*event.Card, "")
+		}
+	case blackjack.EventHoleCardRevealed:
+		if event.Card != nil && !p.quiz {
+			p.show(*event.Card, "Dealer's hole card ")
+		}
+	case blackjack.EventCardBurned:
+		if event.Card != nil && !p.quiz {
+			p.show(*event.Card, "Burned ")
+		}
+	}
+}
+
+// show prints the card just counted and the count after it
+func (p *countPractice) show(card cards.Card, label string) {
+	fmt.Printf("🔢 %s%s %+d → running count %+d, true count %+.1f\n",
+		label, cardGlyph(card), count.Tag(card), p.counter.Running(), p.counter.TrueCount())
+}
+
+// quizRound asks for the running count at the end of a round, then shows the
+// running count and the true count worked out from the estimated decks left. An empty answer skips the question. It returns false if
+// the player quits.
+func (p *countPractice) quizRound() bool {
+	if p == nil || !p.quiz {
+		return true
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("\n🔢 What is the running count? ")
+		scanner.Scan()
+		answer := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "+")
+		if answer == "" {
+			break
+		}
+		if isQuit(answer) {
+			return false
+		}
+		guess, err := strconv.Atoi(answer)
+		if err != nil {
+			fmt.Println("Please enter a number, such as -2 or 3.")
+			continue
+		}
+		p.answered++
+		if guess == p.counter.Running() {
+			p.correct++
+			fmt.Print("✅ Correct! ")
+		} else {
+			fmt.Printf("❌ You said %+d. ", guess)
+		}
+		break
+	}
+	fmt.Printf("The running count is %+d. With about %s decks left, the true count is %+.1f.\n",
+		p.counter.Running(), strconv.FormatFloat(p.counter.EstimatedDecksRemaining(), 'f', -1, 64), p.counter.EstimatedTrueCount())
+	return true
+}
+
+// report prints how many of the count quiz questions were answered correctly
+func (p *countPractice) report() {
+	if p == nil || p.answered == 0 {
+		return
+	}
+	fmt.Printf("Running count quiz: %d of %d correct (%.1f%%)\n", p.correct, p.answered, 100*float64(p.correct)/float64(p.answered))
+}
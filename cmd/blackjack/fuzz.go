@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/rbrabson/blackjack/fuzz"
+)
+
+// runFuzz plays random sequences of operations against the engine, checking its
+// invariants after every step, and reports the seed of each sequence that breaks
+// one so it can be played again
+func runFuzz(args []string) error {
+	fs := flag.NewFlagSet("fuzz", flag.ExitOnError)
+	seed := fs.Uint64("seed", 1, "seed of the first sequence")
+	runs := fs.Int("runs", 1000, "number of sequences to play")
+	steps := fs.Int("steps", 500, "number of operations in each sequence")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *runs <= 0 || *steps <= 0 {
+		return fmt.Errorf("runs and steps must be positive")
+	}
+
+	failed := 0
+	for i := range uint64(*runs) {
+		if err := fuzz.RunSeed(*seed+i, *steps); err != nil {
+			failed++
+			fmt.Printf("❌ seed %d: %v\n", *seed+i, err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sequences broke an invariant", failed, *runs)
+	}
+	fmt.Printf("✅ %d sequences of %d steps kept every invariant.\n", *runs, *steps)
+	return nil
+}
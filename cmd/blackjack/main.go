@@ -2,29 +2,137 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/odds"
+	"github.com/rbrabson/blackjack/strategy"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			if err := runServe(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		case "join":
+			if err := runJoin(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		case "train":
+			if err := runTrain(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		case "script":
+			if err := runScript(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		case "fuzz":
+			if err := runFuzz(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	cfg, err := parseConfig(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if cfg.NoColor || os.Getenv("NO_COLOR") != "" {
+		disableColor()
+	}
+
 	fmt.Println("🃏 Welcome to Blackjack! 🃏")
 	fmt.Println("========================")
 
-	// Create a new game with 6 decks (typical casino setup)
-	game := blackjack.New(6)
+	// Only warnings are logged so the engine's log messages don't interleave with
+	// the game's output.
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	if cfg.TUI {
+		// Nothing is logged while the TUI owns the screen
+		logger = slog.New(slog.DiscardHandler)
+	}
+	// Ask for the shoe and rules unless the command line or a file describes them
+	if cfg.Resume == "" && cfg.askTable {
+		setupTable(&cfg)
+	}
+	game, err := cfg.newGame(logger)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if cfg.Resume != "" {
+		fmt.Printf("Resumed the game saved to %s after %d rounds.\n", cfg.Resume, game.Round())
+		showRules(game)
+		play(game, cfg)
+		return
+	}
+	showRules(game)
+
+	// Setup players, prompting for them unless the configuration lists them
+	if len(cfg.Players) > 0 {
+		for _, player := range cfg.Players {
+			game.AddPlayer(player.Name, blackjack.WithChips(player.Chips))
+			fmt.Printf("Added %s with %d chips.\n", player.Name, player.Chips)
+		}
+	} else {
+		setupPlayers(game)
+	}
+	if cfg.Bots > 0 {
+		seatBots(game, cfg.Bots, cfg.BotStrategy)
+		fmt.Printf("Seated %d computer players playing %s strategy.\n", cfg.Bots, cfg.BotStrategy)
+	}
 
-	// Setup players
-	setupPlayers(game)
+	play(game, cfg)
+}
+
+// play runs the session, in the TUI or with line-based prompts, and shows the
+// final statistics once the players are done
+func play(game *blackjack.Game, cfg tableConfig) {
+	if cfg.TUI {
+		if err := runTUI(game); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		finishRound(game, cfg.QuitPolicy)
+		showFinalStats(game)
+		return
+	}
+
+	practice := startCountPractice(game, cfg.Count)
 
 	// Main game loop
 	for {
 		if !playRound(game) {
 			break
 		}
+		if game.State().ShuffleNext {
+			if cfg.FinishShoe {
+				fmt.Println("\n✂️  Too few cards are left to finish another round, so the shoe will be shuffled.")
+			} else {
+				fmt.Println("\n✂️  The cut card came out, so the shoe will be shuffled before the next round.")
+			}
+		}
+		if !practice.quizRound() {
+			break
+		}
 
 		// Check if any players want to continue
 		if !askToContinue(game) {
@@ -32,8 +140,116 @@ func main() {
 		}
 	}
 
+	finishRound(game, cfg.QuitPolicy)
 	fmt.Println("\n🎉 Thanks for playing Blackjack! 🎉")
 	showFinalStats(game)
+	practice.report()
+}
+
+func showRules(game *blackjack.Game) {
+	rules := game.Rules()
+	soft17 := "hits"
+	if rules.StandSoft17 {
+		soft17 = "stands on"
+	}
+	decks := fmt.Sprintf("%d decks", game.Shoe().NumDecks())
+	if game.Shoe().NumDecks() == 1 {
+		decks = "1 deck"
+	}
+	fmt.Printf("%s, reshuffled after %.0f%% is dealt, dealer %s soft 17, blackjack pays %s\n",
+		decks, game.Shoe().CutPenetration()*100, soft17, rules.BlackjackPays())
+	if rules.CharlieCards > 0 {
+		fmt.Printf("A %d-card hand that hasn't busted wins (Charlie)\n", rules.CharlieCards)
+	}
+	if rules.NoDoubleAfterSplit {
+		fmt.Println("No doubling down after a split")
+	}
+	if rules.NoSurrender {
+		fmt.Println("No surrender")
+	}
+	if rules.MaxSplitHands > 0 && rules.MaxSplitHands < blackjack.MaxHandsPerSpot {
+		fmt.Printf("Pairs may be split into at most %d hands\n", rules.MaxSplitHands)
+	}
+	if rules.ResplitAces {
+		fmt.Println("Split aces may be resplit")
+	}
+	if rules.HitSplitAces {
+		fmt.Println("Split aces may be hit")
+	}
+	if rules.MinBet > 0 {
+		fmt.Printf("Minimum bet: %d\n", rules.MinBet)
+	}
+	if rules.MaxBet > 0 {
+		fmt.Printf("Maximum bet: %d\n", rules.MaxBet)
+	}
+}
+
+// setupTable asks for the number of decks, the penetration, and whether the dealer
+// hits soft 17, keeping the configured value when nothing is entered
+func setupTable(cfg *tableConfig) {
+	scanner := bufio.NewScanner(os.Stdin)
+	fmt.Println("\nTable setup (press Enter to keep the value shown):")
+
+	for {
+		fmt.Printf("Number of decks [%d]: ", cfg.Decks)
+		scanner.Scan()
+		answer := strings.TrimSpace(scanner.Text())
+		if isQuit(answer) {
+			quitSetup()
+		}
+		if answer == "" {
+			break
+		}
+		decks, err := strconv.Atoi(answer)
+		if err != nil || decks <= 0 {
+			fmt.Println("Please enter a positive number of decks.")
+			continue
+		}
+		cfg.Decks = decks
+		break
+	}
+
+	for {
+		fmt.Printf("Percentage of the shoe dealt before reshuffling [%.0f%%]: ", cfg.Penetration*100)
+		scanner.Scan()
+		answer := strings.TrimSuffix(strings.TrimSpace(scanner.Text()), "%")
+		if isQuit(answer) {
+			quitSetup()
+		}
+		if answer == "" {
+			break
+		}
+		percent, err := strconv.ParseFloat(answer, 64)
+		if err != nil || percent <= 0 || percent > 100 {
+			fmt.Println("Please enter a percentage from 1 to 100, such as 75.")
+			continue
+		}
+		cfg.Penetration = percent / 100
+		break
+	}
+
+	for {
+		current := "H17"
+		if !cfg.H17 {
+			current = "S17"
+		}
+		fmt.Printf("Dealer hits (H17) or stands on (S17) soft 17 [%s]: ", current)
+		scanner.Scan()
+		answer := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+		if isQuit(answer) {
+			quitSetup()
+		}
+		if answer == "" {
+			break
+		}
+		if answer != "H17" && answer != "S17" {
+			fmt.Println("Please enter H17 or S17.")
+			continue
+		}
+		cfg.H17 = answer == "H17"
+		break
+	}
+	fmt.Println()
 }
 
 func setupPlayers(game *blackjack.Game) {
@@ -43,6 +259,9 @@ func setupPlayers(game *blackjack.Game) {
 		fmt.Print("\nEnter player name (or 'done' to start): ")
 		scanner.Scan()
 		name := strings.TrimSpace(scanner.Text())
+		if isQuit(name) {
+			quitSetup()
+		}
 
 		if strings.ToLower(name) == "done" {
 			break
@@ -62,6 +281,9 @@ func setupPlayers(game *blackjack.Game) {
 		fmt.Print("Enter starting chips: ")
 		scanner.Scan()
 		chipsStr := strings.TrimSpace(scanner.Text())
+		if isQuit(chipsStr) {
+			quitSetup()
+		}
 		chips, err := strconv.Atoi(chipsStr)
 		if err != nil || chips <= 0 {
 			fmt.Println("Please enter a valid positive number for chips.")
@@ -104,42 +326,39 @@ func playRound(game *blackjack.Game) bool {
 
 	// Show initial game state
 	fmt.Println("\n📋 Initial Cards:")
-	fmt.Println(game.GetGameStatus(false))
+	showTable(game)
 
-	// Check for dealer blackjack
-	if game.Dealer().HasBlackjack() {
+	// The round is settled immediately if the dealer has blackjack
+	if game.Phase() == blackjack.PhaseComplete {
 		fmt.Println("🎯 Dealer has blackjack!")
-		fmt.Println(game.GetGameStatus(true))
-		game.PayoutResults()
+		showTable(game)
 		showRoundResults(game)
 		return true
 	}
 
 	// Player turns
-	playerTurns(game)
+	if !playerTurns(game) {
+		return false
+	}
 
-	// Dealer turn (if any players are still in)
+	// Dealer turn and payouts, once every hand is finished
+	settled, err := game.SettleIfFinished()
+	if err != nil {
+		fmt.Printf("Error settling the round: %v\n", err)
+		return false
+	}
+	if !settled {
+		fmt.Println("Error: the round has hands still to be played")
+		return false
+	}
 	if hasActiveNonBustedPlayers(game) {
 		fmt.Println("\n🎯 Dealer's turn:")
-		fmt.Println("Revealing hole card...")
-		fmt.Println(game.Dealer().RevealHoleCard())
-
-		err = game.DealerPlay()
-		if err != nil {
-			fmt.Printf("Error during dealer play: %v\n", err)
-			return false
-		}
-
-		fmt.Println("\nDealer finished:")
-		fmt.Println(game.Dealer().String())
+		fmt.Println(dealerText(game.Dealer().State()))
 	}
 
 	// Show final results
 	fmt.Println("\n🏁 Final Results:")
-	fmt.Println(game.GetGameStatus(true))
-
-	// Pay out results
-	game.PayoutResults()
+	showTable(game)
 	showRoundResults(game)
 
 	return true
@@ -155,14 +374,33 @@ func placeBets(game *blackjack.Game) bool {
 			continue
 		}
 
+		if isBot(player) {
+			bet, err := placeBotBet(game, player)
+			switch {
+			case err != nil:
+				fmt.Printf("%s could not bet: %v\n", player.Name(), err)
+				player.SetActive(false)
+			case bet == 0:
+				fmt.Printf("%s sits out this round.\n", player.Name())
+				player.SetActive(false)
+			default:
+				fmt.Printf("🤖 %s bet %d chips.\n", player.Name(), bet)
+			}
+			continue
+		}
+
 		for {
-			fmt.Printf("\n%s (Chips: %d), place your bet: ", player.Name(), player.Chips())
+			fmt.Printf("\n%s (Chips: %d), place your bet (or stats): ", player.Name(), player.Chips())
 			scanner.Scan()
 			betStr := strings.TrimSpace(scanner.Text())
 
-			if betStr == "quit" {
+			if isQuit(betStr) {
 				return false
 			}
+			if betStr == "stats" {
+				showStats(game)
+				continue
+			}
 
 			bet, err := strconv.Atoi(betStr)
 			if err != nil {
@@ -170,8 +408,7 @@ func placeBets(game *blackjack.Game) bool {
 				continue
 			}
 
-			hand := player.CurrentHand()
-			err = hand.PlaceBet(bet)
+			err = game.PlaceBet(player.Name(), 0, bet)
 			if err != nil {
 				fmt.Printf("Error: %v\n", err)
 				continue
@@ -195,7 +432,8 @@ func placeBets(game *blackjack.Game) bool {
 	return hasActivePlayers
 }
 
-func playerTurns(game *blackjack.Game) {
+// playerTurns plays each player's hands in turn. It returns false if a player quits.
+func playerTurns(game *blackjack.Game) bool {
 	scanner := bufio.NewScanner(os.Stdin)
 
 	for _, player := range game.Players() {
@@ -206,6 +444,11 @@ func playerTurns(game *blackjack.Game) {
 
 		fmt.Printf("\n🎮 %s's turn:\n", player.Name())
 
+		if isBot(player) {
+			playBot(game, player)
+			continue
+		}
+
 		// Handle all hands for this player (including splits)
 		for player.HasActiveHands() {
 			currentHand := player.CurrentHand()
@@ -226,14 +469,18 @@ func playerTurns(game *blackjack.Game) {
 					player.Name(),
 					player.GetCurrentHandNumber()+1,
 					len(player.Hands()),
-					currentHand.String())
+					handText(currentHand.State()))
 			} else {
-				fmt.Printf("\n%s: %s\n", player.Name(), currentHand.String())
+				fmt.Printf("\n%s: %s\n", player.Name(), handText(currentHand.State()))
 			}
 
 			// Player actions for current hand
 			for currentHand.IsActive() && !currentHand.IsBusted() && !currentHand.IsBlackjack() {
-				fmt.Print("Choose action: (h)it, (s)tand")
+				if currentHand.CanHit() {
+					fmt.Print("Choose action: (h)it, (s)tand")
+				} else {
+					fmt.Print("Choose action: (s)tand")
+				}
 
 				if currentHand.CanDoubleDown() {
 					fmt.Print(", (d)ouble down")
@@ -247,9 +494,12 @@ func playerTurns(game *blackjack.Game) {
 					fmt.Print(", s(u)rrender")
 				}
 
-				fmt.Print(": ")
+				fmt.Print(", or (a)dvice: ")
 				scanner.Scan()
 				action := strings.ToLower(strings.TrimSpace(scanner.Text()))
+				if isQuit(action) {
+					return false
+				}
 
 				switch action {
 				case "h", "hit":
@@ -259,16 +509,15 @@ func playerTurns(game *blackjack.Game) {
 						continue
 					}
 
-					fmt.Printf("Drew: %s\n", currentHand.String())
+					fmt.Printf("Drew: %s\n", handText(currentHand.State()))
 
 					if currentHand.IsBusted() {
 						fmt.Printf("💥 Hand busted!\n")
-						currentHand.SetActive(false)
 					}
 
 				case "s", "stand":
 					fmt.Printf("Standing on hand.\n")
-					err := game.PlayerStand(player.Name())
+					_, err := game.PlayerStand(player.Name())
 					if err != nil {
 						fmt.Printf("Error: %v\n", err)
 						continue
@@ -280,37 +529,25 @@ func playerTurns(game *blackjack.Game) {
 						continue
 					}
 
-					err := currentHand.DoubleDown()
+					err := game.PlayerDoubleDown(player.Name())
 					if err != nil {
 						fmt.Printf("Error: %v\n", err)
 						continue
 					}
 
-					err = game.PlayerDoubleDownHit(player.Name())
-					if err != nil {
-						fmt.Printf("Error: %v\n", err)
-						continue
-					}
-
-					fmt.Printf("Doubled down! Drew: %s\n", currentHand.String())
+					fmt.Printf("Doubled down! Drew: %s\n", handText(currentHand.State()))
 
 					if currentHand.IsBusted() {
 						fmt.Printf("💥 Hand busted!\n")
 					}
 
-					// Double down ends the hand
-					err = game.PlayerStand(player.Name())
-					if err != nil {
-						fmt.Printf("Error: %v\n", err)
-					}
-
 				case "p", "split":
 					if !currentHand.CanSplit() {
 						fmt.Println("Cannot split.")
 						continue
 					}
 
-					err := currentHand.Split()
+					_, err := game.PlayerSplit(player.Name())
 					if err != nil {
 						fmt.Printf("Error: %v\n", err)
 						continue
@@ -318,7 +555,7 @@ func playerTurns(game *blackjack.Game) {
 
 					fmt.Printf("Hand split! You now have %d hands.\n", len(player.Hands()))
 					// Show current hand after split
-					fmt.Printf("Current hand: %s\n", currentHand.String())
+					fmt.Printf("Current hand: %s\n", handText(currentHand.State()))
 
 				case "u", "surrender":
 					if !currentHand.CanSurrender() {
@@ -326,7 +563,7 @@ func playerTurns(game *blackjack.Game) {
 						continue
 					}
 
-					err := game.PlayerSurrender(player.Name())
+					_, err := game.PlayerSurrender(player.Name())
 					if err != nil {
 						fmt.Printf("Error: %v\n", err)
 						continue
@@ -334,8 +571,11 @@ func playerTurns(game *blackjack.Game) {
 
 					fmt.Printf("Surrendered! Half bet returned.\n")
 
+				case "a", "advice":
+					showAdvice(game, currentHand)
+
 				default:
-					fmt.Println("Invalid action. Please choose (h)it, (s)tand, (d)ouble down, s(p)lit, or s(u)rrender if available.")
+					fmt.Println("Invalid action. Please choose (h)it, (s)tand, (d)ouble down, s(p)lit, or s(u)rrender if available, or (a)dvice.")
 				}
 			}
 
@@ -350,13 +590,64 @@ func playerTurns(game *blackjack.Game) {
 
 		fmt.Printf("✅ %s finished all hands.\n", player.Name())
 	}
+	return true
+}
+
+// playBot plays a computer player's hands, printing each action they take
+func playBot(game *blackjack.Game, player *blackjack.Player) {
+	for game.GetActivePlayer() == player {
+		hand := player.CurrentHand()
+		action, err := playBotAction(game, player)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("🤖 %s: %s\n", strategy.ActionName(action), handText(hand.State()))
+	}
+	fmt.Printf("✅ %s finished all hands.\n", player.Name())
+}
+
+// exactAdviceCards is the most cards left in the shoe for advice to include the
+// exact best play for the cards left
+const exactAdviceCards = 26
+
+// showAdvice prints the basic strategy play for the hand, without taking it
+func showAdvice(game *blackjack.Game, hand *blackjack.Hand) {
+	upCard, ok := game.Dealer().UpCard()
+	if !ok {
+		fmt.Println("No advice until the dealer has a card.")
+		return
+	}
+	fmt.Printf("💡 %s\n", strategy.Recommend(hand, upCard, game.Rules()))
+	if hand.Value() < 21 {
+		// The hole card is out of the shoe, but could be any of the cards not yet seen
+		unseen := odds.FromShoe(game.Shoe())
+		if holeCard, ok := game.Dealer().HoleCard(); ok && !game.Dealer().IsHoleCardRevealed() {
+			unseen = unseen.Add(holeCard)
+		}
+		fmt.Printf("📈 %s\n", hitOddsText(odds.HitHand(hand, unseen)))
+		// The exact play doesn't consider splitting, so it isn't given for a pair that may be split
+		if game.Shoe().CardsRemaining() <= exactAdviceCards && !hand.IsSplit() && !hand.CanSplit() {
+			solution := odds.Solve(hand.Cards(), upCard, unseen, game.Rules())
+			fmt.Printf("🧮 For the %d cards left, the best play is %s (EV %+.3f)\n",
+				game.Shoe().CardsRemaining(), strings.ToLower(strategy.ActionName(solution.Best)), solution.EV)
+		}
+	}
+}
+
+// hitOddsText describes the chances of what the next card does to a hand
+func hitOddsText(o odds.HitOdds) string {
+	return fmt.Sprintf("If you hit: %.0f%% to bust, %.0f%% to make 17-21", 100*o.Bust, 100*o.Made)
 }
 
+// hasActiveNonBustedPlayers returns true if any player has a hand, on any spot or
+// split, that is still in against the dealer
 func hasActiveNonBustedPlayers(game *blackjack.Game) bool {
 	for _, player := range game.Players() {
-		hand := player.CurrentHand()
-		if hand.Bet() > 0 && !hand.IsBusted() {
-			return true
+		for _, hand := range player.Hands() {
+			if hand.Bet() > 0 && !hand.IsBusted() && !hand.IsSurrendered() {
+				return true
+			}
 		}
 	}
 	return false
@@ -366,23 +657,8 @@ func showRoundResults(game *blackjack.Game) {
 	fmt.Println("\n💰 Round Results:")
 	fmt.Println("================")
 
-	for _, player := range game.Players() {
-		hands := player.Hands()
-		if len(hands) == 1 {
-			// Single hand
-			result := game.EvaluateHand(player.CurrentHand())
-			fmt.Printf("%s: %s\n", player.Name(), result.String())
-		} else {
-			// Multiple hands (splits)
-			fmt.Printf("%s:\n", player.Name())
-			for idx, hand := range hands {
-				// Temporarily set current hand for evaluation
-				result := game.EvaluateHand(hand)
-				fmt.Printf("  Hand %d: %s\n", idx+1, result.String())
-			}
-		}
-
-		fmt.Printf("  Final Chips: %d\n", player.Chips())
+	if record := game.LastRound(); record != nil {
+		fmt.Println(record.Summary())
 	}
 }
 
@@ -402,11 +678,33 @@ func askToContinue(game *blackjack.Game) bool {
 	}
 
 	scanner := bufio.NewScanner(os.Stdin)
-	fmt.Print("\nPlay another round? (y/n): ")
-	scanner.Scan()
-	response := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	for {
+		fmt.Print("\nPlay another round? (y/n, stats, or save <file>): ")
+		scanner.Scan()
+		response := strings.TrimSpace(scanner.Text())
+
+		if strings.ToLower(response) == "stats" {
+			showStats(game)
+			continue
+		}
+
+		command, path, _ := strings.Cut(response, " ")
+		if strings.ToLower(command) != "save" {
+			response = strings.ToLower(response)
+			return response == "y" || response == "yes"
+		}
 
-	return response == "y" || response == "yes"
+		path = strings.TrimSpace(path)
+		if path == "" {
+			fmt.Println("Please give a file to save to, such as: save blackjack.json")
+			continue
+		}
+		if err := saveGame(game, path); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+		fmt.Printf("💾 Game saved to %s. Continue it later with -resume %s\n", path, path)
+	}
 }
 
 func showFinalStats(game *blackjack.Game) {
@@ -419,4 +717,6 @@ func showFinalStats(game *blackjack.Game) {
 	for _, player := range game.Players() {
 		fmt.Printf("  %s: %d chips\n", player.Name(), player.Chips())
 	}
+
+	showStats(game)
 }
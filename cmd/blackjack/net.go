@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/client"
+	serverhttp "github.com/rbrabson/blackjack/server/http"
+	"github.com/rbrabson/blackjack/server/ws"
+)
+
+// actionCommands are the actions a player may type, by their short and long names
+var actionCommands = map[string]blackjack.ActionType{
+	"h": blackjack.ActionHit, "hit": blackjack.ActionHit,
+	"s": blackjack.ActionStand, "stand": blackjack.ActionStand,
+	"d": blackjack.ActionDouble, "double": blackjack.ActionDouble,
+	"p": blackjack.ActionSplit, "split": blackjack.ActionSplit,
+	"u": blackjack.ActionSurrender, "surrender": blackjack.ActionSurrender,
+}
+
+// runServe hosts a table that players at other terminals join with "blackjack
+// join". It takes the same table flags as a local game.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	cfg, err := parseConfig(fs, args)
+	if err != nil {
+		return err
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	game, err := cfg.newGame(logger)
+	if err != nil {
+		return err
+	}
+
+	server := serverhttp.NewServer(blackjack.WithLogger(logger))
+	table := server.AddTable(game)
+	showRules(game)
+	fmt.Printf("Serving table %s on %s\n", table, *addr)
+	fmt.Printf("Players join with: blackjack join %s -name <name>\n", *addr)
+	return http.ListenAndServe(*addr, server)
+}
+
+// runJoin plays at a table hosted by "blackjack serve" at the address given as the
+// first argument
+func runJoin(args []string) error {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	name := fs.String("name", "", "name to play under (required)")
+	chips := fs.Int("chips", ws.DefaultChips, "chips to join the table with")
+	tableID := fs.String("table", "", "table to join, if the server hosts more than one")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: blackjack join [flags] <addr>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *name == "" {
+		fs.Usage()
+		return fmt.Errorf("join needs the server's address and a player name")
+	}
+
+	ctx := context.Background()
+	c := client.New(serverURL(fs.Arg(0)))
+	table, err := chooseTable(ctx, c, *tableID)
+	if err != nil {
+		return err
+	}
+	sub, err := c.Subscribe(ctx, table, *name)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+	if err := sub.Send(ws.Command{Type: ws.CommandJoin, Chips: *chips}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Joined table %s as %s.\n", table, *name)
+	fmt.Println("Commands: start, bet <amount>, deal, (h)it, (s)tand, (d)ouble, s(p)lit, s(u)rrender, table, quit")
+
+	view := &remoteTable{player: *name}
+	go view.watch(sub)
+	return view.prompt(sub)
+}
+
+// serverURL returns the base URL of a server given as a host and port, such as
+// "localhost:8080"
+func serverURL(addr string) string {
+	if strings.HasPrefix(addr, "http://") || strings.HasPrefix(addr, "https://") {
+		return addr
+	}
+	if strings.HasPrefix(addr, ":") {
+		addr = "localhost" + addr
+	}
+	return "http://" + addr
+}
+
+// chooseTable returns the table to join: the one requested, or the server's only table
+func chooseTable(ctx context.Context, c *client.Client, requested string) (string, error) {
+	if requested != "" {
+		return requested, nil
+	}
+	tables, err := c.ListTables(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list tables: %w", err)
+	}
+	switch len(tables) {
+	case 0:
+		return "", fmt.Errorf("the server is not hosting any tables")
+	case 1:
+		return tables[0], nil
+	default:
+		return "", fmt.Errorf("the server hosts %d tables; choose one with -table (%s)", len(tables), strings.Join(tables, ", "))
+	}
+}
+
+// remoteTable is a networked player's view of a table, kept up to date from the
+// table's messages
+type remoteTable struct {
+	mu       sync.Mutex
+	player   string
+	state    blackjack.GameState
+	quitting bool // quitting is set once the player has left the table
+}
+
+// watch prints the table's events as they happen, and the table whenever it is the
+// player's turn or a round is settled, until the subscription ends
+func (t *remoteTable) watch(sub *client.Subscription) {
+	for msg := range sub.Messages() {
+		t.mu.Lock()
+		switch msg.Type {
+		case ws.MessageEvent:
+			if line := describeEvent(*msg.Event); line != "" {
+				fmt.Println(line)
+			}
+		case ws.MessageError:
+			fmt.Printf("Error: %s\n", msg.Error)
+		case ws.MessageState:
+			previous := t.state
+			t.state = *msg.State
+			t.announce(previous)
+		}
+		t.mu.Unlock()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.quitting {
+		return
+	}
+	if err := sub.Err(); err != nil {
+		fmt.Printf("\nDisconnected: %v\n", err)
+	} else {
+		fmt.Println("\nDisconnected from the table.")
+	}
+	os.Exit(0)
+}
+
+// announce prints the table when the state has changed in a way the player needs
+// to know about. It must be called with the lock held.
+func (t *remoteTable) announce(previous blackjack.GameState) {
+	active := activePlayer(t.state)
+	switch {
+	case t.state.Phase == blackjack.PhaseBetting && (previous.Phase != blackjack.PhaseBetting || previous.Round != t.state.Round):
+		fmt.Printf("\n🎲 Round %d: place your bets with bet <amount>, then deal\n", t.state.Round)
+	case t.state.Phase == blackjack.PhaseComplete && previous.Phase != blackjack.PhaseComplete:
+		fmt.Println("\n🏁 Round settled:")
+		printTable(t.state, "")
+		fmt.Println("Type start for the next round.")
+	case active == t.player && (activePlayer(previous) != t.player || handsChanged(previous, t.state, t.player)):
+		fmt.Println("\n🎮 Your turn:")
+		printTable(t.state, active)
+	}
+}
+
+// prompt reads the player's commands and sends them to the table until the player
+// quits
+func (t *remoteTable) prompt(sub *client.Subscription) error {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fields := strings.Fields(strings.ToLower(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+
+		var cmd ws.Command
+		switch command := fields[0]; command {
+		case "quit", "exit":
+			t.mu.Lock()
+			t.quitting = true
+			t.mu.Unlock()
+			return sub.Send(ws.Command{Type: ws.CommandLeave})
+		case "table":
+			t.mu.Lock()
+			printTable(t.state, activePlayer(t.state))
+			t.mu.Unlock()
+			continue
+		case "start":
+			cmd = ws.Command{Type: ws.CommandStart}
+		case "deal":
+			cmd = ws.Command{Type: ws.CommandDeal}
+		case "bet":
+			amount := 0
+			if len(fields) == 2 {
+				amount, _ = strconv.Atoi(fields[1])
+			}
+			if amount <= 0 {
+				fmt.Println("Please give a bet, such as: bet 10")
+				continue
+			}
+			cmd = ws.Command{Type: ws.CommandBet, Amount: amount}
+		default:
+			action, ok := actionCommands[command]
+			if !ok {
+				fmt.Println("Unknown command. Commands: start, bet <amount>, deal, (h)it, (s)tand, (d)ouble, s(p)lit, s(u)rrender, table, quit")
+				continue
+			}
+			cmd = ws.Command{Type: ws.CommandAction, Action: action}
+		}
+
+		if err := sub.Send(cmd); err != nil {
+			return fmt.Errorf("failed to send %s: %w", cmd.Type, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// activePlayer returns the name of the player whose turn it is, or an empty string
+// if no player is acting
+func activePlayer(state blackjack.GameState) string {
+	if state.Phase != blackjack.PhasePlayerTurns {
+		return ""
+	}
+	for _, player := range state.Players {
+		if !player.Active || player.CurrentHand >= len(player.Hands) {
+			continue
+		}
+		hand := player.Hands[player.CurrentHand]
+		if !hand.Busted && !hand.Blackjack && !hand.Stood {
+			return player.Name
+		}
+	}
+	return ""
+}
+
+// handsChanged returns whether the named player's hands, or the hand they are
+// playing, differ between two states
+func handsChanged(previous, current blackjack.GameState, name string) bool {
+	type summary struct{ current, hands, cards int }
+	summarize := func(state blackjack.GameState) summary {
+		for _, player := range state.Players {
+			if player.Name != name {
+				continue
+			}
+			s := summary{current: player.CurrentHand, hands: len(player.Hands)}
+			for _, hand := range player.Hands {
+				s.cards += len(hand.Cards)
+			}
+			return s
+		}
+		return summary{}
+	}
+	return summarize(previous) != summarize(current)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rbrabson/blackjack"
+)
+
+const (
+	quitStand  = "stand"  // quitStand stands the remaining hands of a round in progress and settles it
+	quitRefund = "refund" // quitRefund abandons a round in progress, refunding its bets
+)
+
+// isQuit returns whether an answer to a prompt asks to quit the game
+func isQuit(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "quit" || answer == "exit"
+}
+
+// quitSetup ends the program when a player quits before any round is played
+func quitSetup() {
+	fmt.Println("\n👋 Goodbye!")
+	os.Exit(0)
+}
+
+// finishRound finishes a round left in progress when the players quit, so no bets
+// are left in limbo. Bets placed before the cards were dealt are always refunded.
+// Once the cards are dealt, the policy either stands every remaining hand and
+// settles the round, or abandons the round and refunds its bets.
+func finishRound(game *blackjack.Game, policy string) {
+	switch game.Phase() {
+	case blackjack.PhaseBetting:
+		bets := outstandingBets(game)
+		if err := game.AbortRound(); err != nil {
+			fmt.Printf("Error refunding the bets: %v\n", err)
+			return
+		}
+		if bets > 0 {
+			fmt.Println("\n↩️  The cards weren't dealt, so the bets were refunded.")
+		}
+	case blackjack.PhasePlayerTurns, blackjack.PhaseDealerTurn:
+		if policy == quitRefund {
+			abandonRound(game)
+			return
+		}
+		if err := standRemaining(game); err != nil {
+			fmt.Printf("Error finishing the round: %v\n", err)
+			abandonRound(game)
+			return
+		}
+		fmt.Println("\n🏁 Final Results (remaining hands stood):")
+		showTable(game)
+		showRoundResults(game)
+	}
+}
+
+// standRemaining stands every hand still being played, letting the computer
+// players finish theirs, then plays the dealer's hand and settles the round
+func standRemaining(game *blackjack.Game) error {
+	for player := game.GetActivePlayer(); player != nil; player = game.GetActivePlayer() {
+		var err error
+		if isBot(player) {
+			_, err = playBotAction(game, player)
+		} else {
+			_, err = game.Act(player.Name(), blackjack.ActionStand)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := game.SettleIfFinished()
+	return err
+}
+
+// abandonRound abandons the round in progress, refunding its bets
+func abandonRound(game *blackjack.Game) {
+	if err := game.AbortRound(); err != nil {
+		fmt.Printf("Error refunding the bets: %v\n", err)
+		return
+	}
+	fmt.Println("\n↩️  The round was abandoned and its bets refunded.")
+}
+
+// outstandingBets returns the total of the bets placed on the round in progress
+func outstandingBets(game *blackjack.Game) int {
+	total := 0
+	for _, player := range game.Players() {
+		for _, hand := range player.Hands() {
+			total += hand.Bet()
+		}
+	}
+	return total
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/strategy"
+)
+
+const (
+	botStrategyKey = "bot_strategy" // botStrategyKey is the metadata key holding a computer player's strategy
+)
+
+// saveGame writes the game to a file, so the session can be continued later with
+// -resume
+func saveGame(game *blackjack.Game, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create save file: %w", err)
+	}
+	if err := game.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// resumeGame loads a game written by saveGame. Seats aren't saved, so the computer
+// players are seated again with the strategies recorded in their metadata.
+func resumeGame(path string, options ...blackjack.GameOption) (*blackjack.Game, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open save file: %w", err)
+	}
+	defer f.Close()
+
+	game, err := blackjack.Load(f, options...)
+	if err != nil {
+		return nil, err
+	}
+	for _, player := range game.Players() {
+		name, ok := player.Metadata(botStrategyKey)
+		if !ok {
+			continue
+		}
+		botStrategy, err := strategy.Lookup(name)
+		if err != nil {
+			return nil, fmt.Errorf("player %s: %w", player.Name(), err)
+		}
+		player.SetSeat(strategy.NewSeat(botStrategy, botBet))
+	}
+	return game, nil
+}
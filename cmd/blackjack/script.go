@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/server/ws"
+)
+
+// scriptUsage describes the commands a script may contain
+const scriptUsage = `Usage: blackjack script [flags] [file]
+
+Plays the commands read from the file, or from standard input if no file is
+given, one per line. Blank lines and lines starting with # are ignored.
+
+  join <player> [chips]     seat a player (with 1000 chips if none are given)
+  leave <player>            remove a player from the table
+  start                     start a new round
+  bet <player> <amount>     place a bet on the player's first spot
+  deal                      deal the initial cards, betting for any computer players
+  <action> <player>         hit, stand, double, split, or surrender the player's hand
+  state                     write the table's state
+
+The results are written to standard output as newline-delimited JSON, using the
+same messages a networked client receives: the outcome of each action, an error
+for each command that fails, and the table's state once each round is settled.
+The exit status is 1 if any command failed.
+
+Flags:
+`
+
+// scriptRunner plays a script against a game, writing the results as JSON
+type scriptRunner struct {
+	game    *blackjack.Game
+	enc     *json.Encoder
+	events  bool                 // events is whether every game event is written
+	failed  bool                 // failed is set once any command has failed
+	settled *blackjack.GameState // settled is the state of a round settled by the last command
+}
+
+// runScript plays a script of commands from a file or standard input, writing the
+// results to standard output
+func runScript(args []string) error {
+	fs := flag.NewFlagSet("script", flag.ExitOnError)
+	events := fs.Bool("events", false, "also write every game event")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), scriptUsage)
+		fs.PrintDefaults()
+	}
+	cfg, err := parseConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return fmt.Errorf("script takes at most one file")
+	}
+
+	input := io.Reader(os.Stdin)
+	if path := fs.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open script: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	// Only warnings are logged, to standard error, so standard output holds nothing
+	// but the results.
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	game, err := cfg.newGame(logger)
+	if err != nil {
+		return err
+	}
+	if cfg.Resume == "" {
+		for _, player := range cfg.Players {
+			game.AddPlayer(player.Name, blackjack.WithChips(player.Chips))
+		}
+		seatBots(game, cfg.Bots, cfg.BotStrategy)
+	}
+
+	runner := &scriptRunner{game: game, enc: json.NewEncoder(os.Stdout), events: *events}
+	game.AddListener(runner)
+	if err := runner.run(input); err != nil {
+		return err
+	}
+	if runner.failed {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// OnEvent writes the event, if every event is being written
+func (r *scriptRunner) OnEvent(event blackjack.Event) {
+	if r.events {
+		r.write(ws.Message{Type: ws.MessageEvent, Event: &event})
+	}
+}
+
+// run plays each command in the script in turn
+func (r *scriptRunner) run(input io.Reader) error {
+	scanner := bufio.NewScanner(input)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		command, outcome, err := r.execute(fields)
+		switch {
+		case err != nil:
+			r.failed = true
+			r.write(ws.Message{Type: ws.MessageError, Command: command, Error: fmt.Sprintf("line %d: %v", line, err)})
+		case outcome != nil:
+			r.write(ws.Message{Type: ws.MessageOutcome, Command: command, Outcome: outcome})
+		}
+		if r.settled != nil {
+			r.write(ws.Message{Type: ws.MessageState, State: r.settled})
+			r.settled = nil
+		}
+	}
+	return scanner.Err()
+}
+
+// execute runs a single command from the script, returning the command it was and
+// the outcome of any action
+func (r *scriptRunner) execute(fields []string) (ws.CommandType, *blackjack.ActionOutcome, error) {
+	game := r.game
+	name, args := strings.ToLower(fields[0]), fields[1:]
+	switch name {
+	case "start":
+		return ws.CommandStart, nil, game.StartNewRound()
+	case "deal":
+		return ws.CommandDeal, nil, r.deal()
+	case "state":
+		state := game.State()
+		r.write(ws.Message{Type: ws.MessageState, State: &state})
+		return "", nil, nil
+	case "join":
+		if len(args) < 1 || len(args) > 2 {
+			return ws.CommandJoin, nil, fmt.Errorf("usage: join <player> [chips]")
+		}
+		chips := ws.DefaultChips
+		if len(args) == 2 {
+			var err error
+			if chips, err = strconv.Atoi(args[1]); err != nil || chips <= 0 {
+				return ws.CommandJoin, nil, fmt.Errorf("chips must be a positive number")
+			}
+		}
+		if game.GetPlayer(args[0]) != nil {
+			return ws.CommandJoin, nil, fmt.Errorf("player %s is already seated", args[0])
+		}
+		game.AddPlayer(args[0], blackjack.WithChips(chips))
+		return ws.CommandJoin, nil, nil
+	case "leave":
+		if len(args) != 1 {
+			return ws.CommandLeave, nil, fmt.Errorf("usage: leave <player>")
+		}
+		return ws.CommandLeave, nil, game.RemovePlayer(args[0])
+	case "bet":
+		if len(args) != 2 {
+			return ws.CommandBet, nil, fmt.Errorf("usage: bet <player> <amount>")
+		}
+		amount, err := strconv.Atoi(args[1])
+		if err != nil {
+			return ws.CommandBet, nil, fmt.Errorf("invalid bet %q", args[1])
+		}
+		return ws.CommandBet, nil, game.PlaceBet(args[0], 0, amount)
+	}
+
+	action, ok := actionCommands[name]
+	if !ok {
+		return ws.CommandType(name), nil, fmt.Errorf("unknown command %q", fields[0])
+	}
+	if len(args) != 1 {
+		return ws.CommandAction, nil, fmt.Errorf("usage: %s <player>", name)
+	}
+	outcome, err := game.Act(args[0], action)
+	if err != nil {
+		return ws.CommandAction, nil, err
+	}
+	return ws.CommandAction, &outcome, r.settle()
+}
+
+// deal places the computer players' bets and deals the initial cards
+func (r *scriptRunner) deal() error {
+	for _, player := range r.game.Players() {
+		if !isBot(player) || !player.IsActive() || player.CurrentHand().Bet() > 0 {
+			continue
+		}
+		if bet, err := placeBotBet(r.game, player); err != nil || bet == 0 {
+			player.SetActive(false)
+		}
+	}
+	if err := r.game.DealInitialCards(); err != nil {
+		return err
+	}
+	return r.settle()
+}
+
+// settle plays the computer players' hands until it is a player's turn, then plays
+// the dealer's hand and settles the round once the players are done
+func (r *scriptRunner) settle() error {
+	for player := r.game.GetActivePlayer(); player != nil && isBot(player); player = r.game.GetActivePlayer() {
+		if _, err := playBotAction(r.game, player); err != nil {
+			return err
+		}
+	}
+	settled, err := r.game.SettleIfFinished()
+	if err != nil || !settled {
+		return err
+	}
+	state := r.game.State()
+	r.settled = &state
+	return nil
+}
+
+// write writes a message as a line of JSON
+func (r *scriptRunner) write(msg ws.Message) {
+	if err := r.enc.Encode(msg); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write results: %v\n", err)
+		os.Exit(1)
+	}
+}
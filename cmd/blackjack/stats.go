@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rbrabson/blackjack"
+)
+
+const (
+	trajectoryWidth = 40 // trajectoryWidth is the number of rounds shown in a bankroll trajectory
+)
+
+// sparkLevels are the bars used to draw a bankroll trajectory, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// showStats prints each player's results from the engine's statistics, along with
+// the trajectory of their chips over the recent rounds
+func showStats(game *blackjack.Game) {
+	for _, player := range game.Players() {
+		stats := player.Stats()
+		fmt.Printf("\n%s\n", player.Name())
+		if stats.Rounds == 0 {
+			fmt.Println("  No rounds played yet.")
+			continue
+		}
+		fmt.Printf("  Rounds: %d  Hands: %d\n", stats.Rounds, stats.Hands)
+		fmt.Printf("  W/L/P: %d/%d/%d  Blackjacks: %d  Charlies: %d  Busts: %d  Surrenders: %d\n",
+			stats.Wins, stats.Losses, stats.Pushes, stats.Blackjacks, stats.Charlies, stats.Busts, stats.Surrenders)
+		fmt.Printf("  Wagered: %d  Net: %+d  Biggest win: %d\n", stats.TotalWagered, stats.Net, stats.BiggestWin)
+		if chips := game.ChipHistory(player.Name()); len(chips) > 1 {
+			fmt.Printf("  Chips: %d %s %d\n", chips[0], sparkline(chips), chips[len(chips)-1])
+		}
+	}
+}
+
+// sparkline draws the most recent values as a line of bars scaled between the
+// lowest and highest of them
+func sparkline(values []int) string {
+	if len(values) > trajectoryWidth {
+		values = values[len(values)-trajectoryWidth:]
+	}
+	low, high := slices.Min(values), slices.Max(values)
+
+	var line strings.Builder
+	for _, value := range values {
+		level := len(sparkLevels) / 2
+		if high > low {
+			level = (value - low) * (len(sparkLevels) - 1) / (high - low)
+		}
+		line.WriteRune(sparkLevels[level])
+	}
+	return line.String()
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/odds"
+	"github.com/rbrabson/blackjack/strategy"
+	"github.com/rbrabson/cards"
+)
+
+// trainDecks is the number of decks in the shoe the trainer's odds are worked out for
+const trainDecks = 6
+
+// trainCategories are the kinds of hands the trainer deals, in the order they are
+// reported
+var trainCategories = []string{"hard", "soft", "pairs"}
+
+// trainScore is how many hands of a category were answered, and how many of them
+// correctly
+type trainScore struct {
+	correct int
+	total   int
+}
+
+// trainer deals random opening hands and grades the player's plays against basic
+// strategy
+type trainer struct {
+	rng        *rand.Rand
+	rules      blackjack.Rules
+	categories []string               // categories are the kinds of hands to deal
+	scores     map[string]*trainScore // scores are the results for each category
+}
+
+// runTrain quizzes the player on basic strategy until they quit or have played the
+// number of hands asked for
+func runTrain(args []string) error {
+	fs := flag.NewFlagSet("train", flag.ExitOnError)
+	hands := fs.Int("hands", 0, "number of hands to deal (0 to play until you quit)")
+	category := fs.String("category", "all", fmt.Sprintf("kind of hands to practice (all, %s)", strings.Join(trainCategories, ", ")))
+	h17 := fs.Bool("h17", true, "dealer hits soft 17 (use -h17=false for the dealer to stand)")
+	das := fs.Bool("das", true, "doubling down is allowed after a split")
+	seed := fs.Uint64("seed", 0, "seed for dealing reproducible hands (0 for random hands)")
+	noColor := fs.Bool("no-color", false, "print plain text without colors")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *hands < 0 {
+		return fmt.Errorf("hands must not be negative")
+	}
+	if *noColor || os.Getenv("NO_COLOR") != "" {
+		disableColor()
+	}
+
+	t := &trainer{
+		rules:      blackjack.DefaultRules(),
+		categories: trainCategories,
+		scores:     make(map[string]*trainScore, len(trainCategories)),
+	}
+	if *category != "all" {
+		if !slices.Contains(trainCategories, *category) {
+			return fmt.Errorf("unknown category %q (choose from all, %s)", *category, strings.Join(trainCategories, ", "))
+		}
+		t.categories = []string{*category}
+	}
+	t.rules.StandSoft17 = !*h17
+	t.rules.NoDoubleAfterSplit = !*das
+	if *seed != 0 {
+		t.rng = rand.New(rand.NewPCG(*seed, *seed))
+	} else {
+		t.rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	for _, name := range trainCategories {
+		t.scores[name] = &trainScore{}
+	}
+
+	fmt.Println("🎓 Basic Strategy Trainer")
+	fmt.Println("========================")
+	fmt.Printf("The dealer %s soft 17 and doubling after a split is %s.\n", map[bool]string{true: "hits", false: "stands on"}[*h17], map[bool]string{true: "allowed", false: "not allowed"}[*das])
+	fmt.Println("Answer with (h)it, (s)tand, (d)ouble, s(p)lit, or s(u)rrender; type quit to stop.")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for played := 0; *hands == 0 || played < *hands; played++ {
+		if !t.quiz(scanner, played+1) {
+			break
+		}
+	}
+	t.report()
+	return scanner.Err()
+}
+
+// quiz deals a hand, asks for the play, and grades the answer. It returns false if
+// the player quits.
+func (t *trainer) quiz(scanner *bufio.Scanner, number int) bool {
+	category := t.categories[t.rng.IntN(len(t.categories))]
+	first, second := t.deal(category)
+	upCard := t.card(cards.Ranks[t.rng.IntN(len(cards.Ranks))])
+	advice := strategy.Opening(first, second, upCard, t.rules)
+
+	fmt.Printf("\nHand %d: %s %s vs the dealer's %s\n", number, cardGlyph(first), cardGlyph(second), cardGlyph(upCard))
+	for {
+		fmt.Print("Your play? ")
+		if !scanner.Scan() {
+			return false
+		}
+		answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if answer == "quit" || answer == "q" || answer == "exit" {
+			return false
+		}
+		action, ok := actionCommands[answer]
+		if !ok {
+			fmt.Println("Please answer h, s, d, p, or u.")
+			continue
+		}
+		if action == blackjack.ActionSplit && first.Rank != second.Rank {
+			fmt.Println("Only a pair may be split.")
+			continue
+		}
+
+		score := t.scores[category]
+		score.total++
+		if action == advice.Action {
+			score.correct++
+			fmt.Printf("✅ Correct. %s\n", advice)
+		} else {
+			fmt.Printf("❌ Basic strategy says %s\n", advice)
+		}
+		value, soft := odds.Value(first)+odds.Value(second), first.Rank == cards.Ace || second.Rank == cards.Ace
+		if soft {
+			value += 10
+		}
+		unseen := odds.FullShoe(trainDecks).Remove(first, second, upCard)
+		fmt.Printf("📈 %s\n", hitOddsText(odds.Hit(value, soft, unseen)))
+		return true
+	}
+}
+
+// deal returns two cards making a hand of the category: a hard total, a soft total,
+// or a pair. A blackjack is never dealt, as there is no play to make.
+func (t *trainer) deal(category string) (cards.Card, cards.Card) {
+	switch category {
+	case "pairs":
+		rank := cards.Ranks[t.rng.IntN(10)] // ace through ten, so ten-value pairs aren't dealt more often
+		if rank == cards.Ten {
+			rank = cards.Ranks[9+t.rng.IntN(4)]
+		}
+		first, second := t.card(rank), t.card(rank)
+		for second == first {
+			second = t.card(rank)
+		}
+		return first, second
+	case "soft":
+		return t.card(cards.Ace), t.card(cards.Ranks[1+t.rng.IntN(8)]) // two through nine
+	default:
+		for {
+			first, second := cards.Ranks[1+t.rng.IntN(12)], cards.Ranks[1+t.rng.IntN(12)]
+			if first != second {
+				return t.card(first), t.card(second)
+			}
+		}
+	}
+}
+
+// card returns a card of the rank in a random suit
+func (t *trainer) card(rank cards.Rank) cards.Card {
+	return cards.Card{Suit: cards.Suits[t.rng.IntN(len(cards.Suits))], Rank: rank}
+}
+
+// report prints the player's accuracy for each category of hand and overall
+func (t *trainer) report() {
+	fmt.Println("\n📊 Trainer Results:")
+	fmt.Println("===================")
+	var overall trainScore
+	for _, name := range trainCategories {
+		score := t.scores[name]
+		if score.total == 0 {
+			continue
+		}
+		fmt.Printf("%-8s %s\n", strings.ToUpper(name[:1])+name[1:]+":", score)
+		overall.correct += score.correct
+		overall.total += score.total
+	}
+	if overall.total == 0 {
+		fmt.Println("No hands were answered.")
+		return
+	}
+	fmt.Printf("%-8s %s\n", "Overall:", overall)
+}
+
+// String returns the score as correct answers out of the total and a percentage
+func (s trainScore) String() string {
+	return fmt.Sprintf("%d/%d (%.1f%%)", s.correct, s.total, 100*float64(s.correct)/float64(s.total))
+}
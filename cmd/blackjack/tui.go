@@ -0,0 +1,482 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/cards"
+)
+
+const (
+	eventLogSize = 200 // eventLogSize is the number of events kept for the event log panel
+)
+
+// tuiStage is the part of the round the TUI is waiting on
+type tuiStage int
+
+const (
+	stageBetting   tuiStage = iota // stageBetting is waiting for each player's bet
+	stagePlaying                   // stagePlaying is waiting for the active player's action
+	stageRoundOver                 // stageRoundOver is showing the results of the round
+	stageGameOver                  // stageGameOver is when no player has chips left
+)
+
+// tuiAction is an action button shown while a player is acting
+type tuiAction struct {
+	key    string
+	label  string
+	action blackjack.ActionType
+}
+
+// tuiActions are the action buttons, in the order they are shown
+var tuiActions = []tuiAction{
+	{"h", "Hit", blackjack.ActionHit},
+	{"s", "Stand", blackjack.ActionStand},
+	{"d", "Double", blackjack.ActionDouble},
+	{"p", "Split", blackjack.ActionSplit},
+	{"u", "Surrender", blackjack.ActionSurrender},
+}
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+	panelStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	activeStyle   = panelStyle.BorderForeground(lipgloss.Color("10"))
+	cardStyle     = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Width(3).Align(lipgloss.Center)
+	redCardStyle  = cardStyle.Foreground(lipgloss.Color("9"))
+	holeCardStyle = cardStyle.Foreground(lipgloss.Color("8"))
+	buttonStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	disabledStyle = buttonStyle.Foreground(lipgloss.Color("8")).BorderForeground(lipgloss.Color("8"))
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+)
+
+// eventLog collects the game's events as lines of text for the event log panel
+type eventLog struct {
+	lines []string
+}
+
+// OnEvent adds a description of the event to the log
+func (l *eventLog) OnEvent(event blackjack.Event) {
+	line := describeEvent(event)
+	if line == "" {
+		return
+	}
+	l.lines = append(l.lines, line)
+	if len(l.lines) > eventLogSize {
+		l.lines = l.lines[len(l.lines)-eventLogSize:]
+	}
+}
+
+// tuiModel is the Bubble Tea model for playing the game in a full-screen terminal UI
+type tuiModel struct {
+	game    *blackjack.Game
+	events  *eventLog
+	stage   tuiStage
+	bettor  int    // bettor is the index of the player whose bet is being entered
+	input   string // input is the bet being typed
+	message string // message is feedback on the last key pressed, such as an error
+	width   int
+	height  int
+}
+
+// runTUI plays the game in a full-screen terminal UI until the players quit
+func runTUI(game *blackjack.Game) error {
+	events := &eventLog{}
+	game.AddListener(events)
+
+	model := &tuiModel{game: game, events: events}
+	model.startRound()
+
+	_, err := tea.NewProgram(model, tea.WithAltScreen()).Run()
+	return err
+}
+
+// Init implements tea.Model
+func (m *tuiModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model, handling a key press or a change in the window size
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		key := msg.String()
+		if key == "ctrl+c" || key == "esc" || (key == "q" && m.stage != stageBetting) {
+			return m, tea.Quit
+		}
+		m.message = ""
+		switch m.stage {
+		case stageBetting:
+			m.betKey(msg)
+		case stagePlaying:
+			m.actionKey(key)
+		case stageRoundOver:
+			if key == "enter" || key == "n" {
+				m.startRound()
+			}
+		case stageGameOver:
+			if key == "enter" {
+				return m, tea.Quit
+			}
+		}
+	}
+	return m, nil
+}
+
+// startRound starts a new round and begins taking bets
+func (m *tuiModel) startRound() {
+	if !m.anyChips() {
+		m.stage = stageGameOver
+		return
+	}
+	if err := m.game.StartNewRound(); err != nil {
+		m.message = fmt.Sprintf("Error starting round: %v", err)
+		m.stage = stageRoundOver
+		return
+	}
+	for _, player := range m.game.Players() {
+		if player.Chips() <= 0 {
+			player.SetActive(false)
+		}
+	}
+	m.stage = stageBetting
+	m.bettor = -1
+	m.input = ""
+	m.nextBettor()
+}
+
+// anyChips returns whether any player has chips left to bet
+func (m *tuiModel) anyChips() bool {
+	for _, player := range m.game.Players() {
+		if player.Chips() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// nextBettor moves on to the next player who can bet, dealing the cards once every
+// player has bet
+func (m *tuiModel) nextBettor() {
+	players := m.game.Players()
+	for m.bettor++; m.bettor < len(players); m.bettor++ {
+		player := players[m.bettor]
+		if !player.IsActive() {
+			continue
+		}
+		if !isBot(player) {
+			return
+		}
+		if bet, err := placeBotBet(m.game, player); err != nil || bet == 0 {
+			player.SetActive(false)
+		}
+	}
+	m.deal()
+}
+
+// betKey handles a key press while a player is entering their bet. An empty bet
+// sits the player out for the round.
+func (m *tuiModel) betKey(msg tea.KeyMsg) {
+	player := m.game.Players()[m.bettor]
+	switch msg.Type {
+	case tea.KeyBackspace:
+		if m.input != "" {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case tea.KeyEnter:
+		if m.input == "" {
+			player.SetActive(false)
+			m.nextBettor()
+			return
+		}
+		bet, err := strconv.Atoi(m.input)
+		m.input = ""
+		if err != nil {
+			m.message = "Please enter a valid number."
+			return
+		}
+		if err := m.game.PlaceBet(player.Name(), 0, bet); err != nil {
+			m.message = fmt.Sprintf("Error: %v", err)
+			return
+		}
+		m.nextBettor()
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if r >= '0' && r <= '9' {
+				m.input += string(r)
+			}
+		}
+	}
+}
+
+// deal deals the initial cards once the bets are in
+func (m *tuiModel) deal() {
+	betting := false
+	for _, player := range m.game.Players() {
+		if player.IsActive() && player.CurrentHand().Bet() > 0 {
+			betting = true
+			break
+		}
+	}
+	if !betting {
+		m.message = "No bets were placed."
+		if err := m.game.AbortRound(); err != nil {
+			m.message = fmt.Sprintf("Error refunding the bets: %v", err)
+		}
+		m.stage = stageRoundOver
+		return
+	}
+
+	if err := m.game.DealInitialCards(); err != nil {
+		m.message = fmt.Sprintf("Error dealing cards: %v", err)
+		m.stage = stageRoundOver
+		return
+	}
+	m.stage = stagePlaying
+	m.settle()
+}
+
+// actionKey handles a key press while a player is acting on their hand
+func (m *tuiModel) actionKey(key string) {
+	player := m.game.GetActivePlayer()
+	if player == nil {
+		m.settle()
+		return
+	}
+	for _, button := range tuiActions {
+		if button.key != key {
+			continue
+		}
+		if _, err := m.game.Act(player.Name(), button.action); err != nil {
+			m.message = fmt.Sprintf("Error: %v", err)
+			return
+		}
+		m.settle()
+		return
+	}
+}
+
+// settle plays the computer players' hands until it is a player's turn, then plays
+// the dealer's hand and settles the round once the players are done
+func (m *tuiModel) settle() {
+	if m.game.Phase() == blackjack.PhaseComplete {
+		m.stage = stageRoundOver
+		return
+	}
+	for player := m.game.GetActivePlayer(); player != nil && isBot(player); player = m.game.GetActivePlayer() {
+		if _, err := playBotAction(m.game, player); err != nil {
+			m.message = fmt.Sprintf("Error: %v", err)
+			return
+		}
+	}
+	settled, err := m.game.SettleIfFinished()
+	if err != nil {
+		m.message = fmt.Sprintf("Error settling round: %v", err)
+		return
+	}
+	if settled {
+		m.stage = stageRoundOver
+	}
+}
+
+// View implements tea.Model, drawing the table, the prompt, and the event log
+func (m *tuiModel) View() string {
+	state := m.game.State()
+
+	payout := m.game.Rules().BlackjackPays()
+	title := titleStyle.Render(fmt.Sprintf("🃏 Blackjack — Round %d", state.Round))
+	info := dimStyle.Render(fmt.Sprintf("%d cards in the shoe · blackjack pays %s", state.CardsRemaining, payout))
+
+	table := []string{title, info, m.viewDealer(state.Dealer)}
+	active := m.game.GetActivePlayer()
+	for _, player := range state.Players {
+		table = append(table, m.viewPlayer(player, active))
+	}
+	table = append(table, m.viewPrompt(active))
+	if m.message != "" {
+		table = append(table, errorStyle.Render(m.message))
+	}
+	left := lipgloss.JoinVertical(lipgloss.Left, table...)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, " ", m.viewEvents(lipgloss.Height(left)))
+}
+
+// viewDealer draws the dealer's cards
+func (m *tuiModel) viewDealer(dealer blackjack.DealerState) string {
+	header := "Dealer"
+	if len(dealer.Cards) > 0 {
+		header = fmt.Sprintf("Dealer · %d", dealer.Value)
+	}
+	cardViews := make([]string, 0, len(dealer.Cards))
+	for _, card := range dealer.Cards {
+		cardViews = append(cardViews, viewCard(card))
+	}
+	return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, header, lipgloss.JoinHorizontal(lipgloss.Top, cardViews...)))
+}
+
+// viewPlayer draws a player's chips and hands, highlighting the hand being played
+func (m *tuiModel) viewPlayer(player blackjack.PlayerState, active *blackjack.Player) string {
+	acting := m.stage == stagePlaying && active != nil && active.Name() == player.Name
+	header := fmt.Sprintf("%s · %d chips", player.Name, player.Chips)
+	if !player.Active && m.stage == stageBetting {
+		header += dimStyle.Render(" · sitting out")
+	}
+
+	hands := make([]string, 0, len(player.Hands))
+	for i, hand := range player.Hands {
+		if len(hand.Cards) == 0 {
+			continue
+		}
+		cardViews := make([]string, 0, len(hand.Cards))
+		for _, card := range hand.Cards {
+			cardViews = append(cardViews, viewCard(&card))
+		}
+		style := panelStyle
+		if acting && i == player.CurrentHand {
+			style = activeStyle
+		}
+		hands = append(hands, style.Render(lipgloss.JoinVertical(lipgloss.Left,
+			handSummary(hand, len(player.Hands) > 1),
+			lipgloss.JoinHorizontal(lipgloss.Top, cardViews...),
+		)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, lipgloss.JoinHorizontal(lipgloss.Top, hands...))
+}
+
+// handSummary describes a hand's value, bet, and result
+func handSummary(hand blackjack.HandState, label bool) string {
+	parts := make([]string, 0, 4)
+	if label {
+		parts = append(parts, fmt.Sprintf("Hand %d", hand.ID))
+	}
+	value := strconv.Itoa(hand.Value)
+	switch {
+	case hand.Blackjack:
+		value = "Blackjack"
+	case hand.Busted:
+		value = fmt.Sprintf("%d bust", hand.Value)
+	case hand.Soft:
+		value = fmt.Sprintf("soft %d", hand.Value)
+	}
+	parts = append(parts, value, fmt.Sprintf("bet %d", hand.Bet))
+	if hand.Result != 0 {
+		parts = append(parts, hand.Result.String())
+	}
+	return strings.Join(parts, " · ")
+}
+
+// viewCard draws a card, or the back of a card for the dealer's hidden hole card
+func viewCard(card *cards.Card) string {
+	if card == nil {
+		return holeCardStyle.Render("░░░")
+	}
+	if isRed(*card) {
+		return redCardStyle.Render(cardLabel(*card))
+	}
+	return cardStyle.Render(cardLabel(*card))
+}
+
+// viewPrompt draws the bet input, the action buttons, or what to do next
+func (m *tuiModel) viewPrompt(active *blackjack.Player) string {
+	switch m.stage {
+	case stageBetting:
+		player := m.game.Players()[m.bettor]
+		return fmt.Sprintf("\n%s, place your bet: %s█\n%s", player.Name(), m.input,
+			dimStyle.Render("enter to bet · empty bet to sit out · esc to quit"))
+	case stagePlaying:
+		if active == nil {
+			return ""
+		}
+		available := active.AvailableActions()
+		buttons := make([]string, 0, len(tuiActions))
+		for _, button := range tuiActions {
+			text := fmt.Sprintf("(%s) %s", button.key, button.label)
+			if containsAction(available, button.action) {
+				buttons = append(buttons, buttonStyle.Render(text))
+			} else {
+				buttons = append(buttons, disabledStyle.Render(text))
+			}
+		}
+		return lipgloss.JoinVertical(lipgloss.Left,
+			fmt.Sprintf("\n%s, choose an action:", active.Name()),
+			lipgloss.JoinHorizontal(lipgloss.Top, buttons...))
+	case stageRoundOver:
+		return "\n" + dimStyle.Render("enter for the next round · q to quit")
+	default:
+		return "\nNo players have chips remaining. Game over!\n" + dimStyle.Render("enter to quit")
+	}
+}
+
+// containsAction returns whether the action is one of the actions given
+func containsAction(actions []blackjack.ActionType, action blackjack.ActionType) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// viewEvents draws the most recent events, fitting the panel to the given height
+func (m *tuiModel) viewEvents(height int) string {
+	lines := max(1, height-2)
+	if m.height > 0 {
+		lines = max(lines, m.height-2)
+	}
+	events := m.events.lines
+	if len(events) > lines-1 {
+		events = events[len(events)-(lines-1):]
+	}
+	body := append([]string{titleStyle.Render("Events")}, events...)
+	width := 40
+	if m.width > 0 {
+		width = max(24, min(48, m.width/3))
+	}
+	return panelStyle.Width(width).Height(lines).Render(strings.Join(body, "\n"))
+}
+
+// describeEvent returns a line of text describing a game event for the event log
+func describeEvent(event blackjack.Event) string {
+	who := event.Player
+	if who == "" {
+		who = "Dealer"
+	}
+	switch event.Type {
+	case blackjack.EventRoundStarted:
+		return "── New round ──"
+	case blackjack.EventCardDealt:
+		if event.Card == nil {
+			return "Dealer dealt the hole card"
+		}
+		return fmt.Sprintf("%s dealt %s", who, cardLabel(*event.Card))
+	case blackjack.EventHoleCardRevealed:
+		return fmt.Sprintf("Dealer revealed %s", cardLabel(*event.Card))
+	case blackjack.EventCardBurned:
+		return fmt.Sprintf("Dealer burned %s", cardLabel(*event.Card))
+	case blackjack.EventCutCardReached:
+		return "Cut card out, shuffling after this round"
+	case blackjack.EventShoeShuffled:
+		return "Shoe shuffled"
+	case blackjack.EventHandBusted:
+		return fmt.Sprintf("%s busted", who)
+	case blackjack.EventTurnEnded:
+		return fmt.Sprintf("%s finished", who)
+	case blackjack.EventChipsChanged:
+		return fmt.Sprintf("%s %+d (%s) → %d", who, event.Amount, event.Reason, event.Balance)
+	case blackjack.EventPlayerJoined:
+		return fmt.Sprintf("%s joined", who)
+	case blackjack.EventRoundCompleted:
+		return "Round settled"
+	case blackjack.EventRoundAborted:
+		return "Round abandoned"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,120 @@
+// Package count keeps a Hi-Lo card count of the cards dealt from a game's shoe,
+// for players practicing card counting.
+package count
+
+import (
+	"math"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/cards"
+)
+
+// Tag returns the Hi-Lo value of a card: +1 for a two through six, 0 for a seven
+// through nine, and -1 for a ten-value card or an ace
+func Tag(card cards.Card) int {
+	switch card.Rank {
+	case cards.Two, cards.Three, cards.Four, cards.Five, cards.Six:
+		return 1
+	case cards.Seven, cards.Eight, cards.Nine:
+		return 0
+	default:
+		return -1
+	}
+}
+
+// EstimateDecks estimates the decks left in a shoe of numDecks decks once dealt
+// cards have been dealt, rounded to the nearest quarter deck the way a counter
+// judges the discard tray. At least a quarter deck is always estimated to remain.
+func EstimateDecks(dealt, numDecks int) float64 {
+	remaining := float64(numDecks*blackjack.NumCardsInDeck-dealt) / blackjack.NumCardsInDeck
+	return max(0.25, math.Round(remaining*4)/4)
+}
+
+// TrueCount divides a running count by the decks remaining
+func TrueCount(running int, decks float64) float64 {
+	if decks <= 0 {
+		return float64(running)
+	}
+	return float64(running) / decks
+}
+
+// Counter keeps the Hi-Lo count of the cards dealt from a shoe. It is a game
+// listener, counting each card as it is dealt or burned face up and the dealer's
+// hole card once it is revealed, and starting over whenever the shoe is reshuffled.
+type Counter struct {
+	shoe      *blackjack.Shoe
+	running   int // running is the sum of the tags of the cards counted
+	counted   int // counted is the number of cards counted since the shoe was shuffled
+	remaining int // remaining is the number of cards left in the shoe when last checked
+}
+
+// NewCounter returns a counter for the cards dealt from the shoe
+func NewCounter(shoe *blackjack.Shoe) *Counter {
+	return &Counter{shoe: shoe, remaining: shoe.CardsRemaining()}
+}
+
+// OnEvent counts the cards dealt in a game
+func (c *Counter) OnEvent(event blackjack.Event) {
+	// The shoe has more cards than before only if it has been reshuffled
+	if remaining := c.shoe.CardsRemaining(); remaining > c.remaining {
+		c.Reset()
+	} else {
+		c.remaining = remaining
+	}
+
+	switch event.Type {
+	case blackjack.EventShoeShuffled:
+		c.Reset()
+	case blackjack.EventCardDealt, blackjack.EventHoleCardRevealed, blackjack.EventCardBurned:
+		if event.Card != nil {
+			c.Count(*event.Card)
+		}
+	}
+}
+
+// Count adds a card to the count
+func (c *Counter) Count(card cards.Card) {
+	c.running += Tag(card)
+	c.counted++
+}
+
+// Reset starts the count over for a freshly shuffled shoe
+func (c *Counter) Reset() {
+	c.running = 0
+	c.counted = 0
+	c.remaining = c.shoe.CardsRemaining()
+}
+
+// Running returns the running count
+func (c *Counter) Running() int {
+	return c.running
+}
+
+// Counted returns the number of cards counted since the shoe was shuffled
+func (c *Counter) Counted() int {
+	return c.counted
+}
+
+// DecksRemaining returns the number of decks left in the shoe
+func (c *Counter) DecksRemaining() float64 {
+	return float64(c.shoe.CardsRemaining()) / blackjack.NumCardsInDeck
+}
+
+// EstimatedDecksRemaining returns the decks left in the shoe to the nearest quarter
+// deck, as a counter would estimate them
+func (c *Counter) EstimatedDecksRemaining() float64 {
+	dealt := c.shoe.NumDecks()*blackjack.NumCardsInDeck - c.shoe.CardsRemaining()
+	return EstimateDecks(dealt, c.shoe.NumDecks())
+}
+
+// TrueCount returns the running count divided by the number of decks left in the
+// shoe
+func (c *Counter) TrueCount() float64 {
+	return TrueCount(c.running, c.DecksRemaining())
+}
+
+// EstimatedTrueCount returns the running count divided by the estimated number of
+// decks left in the shoe, the true count a counter would work out at the table
+func (c *Counter) EstimatedTrueCount() float64 {
+	return TrueCount(c.running, c.EstimatedDecksRemaining())
+}
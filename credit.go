@@ -0,0 +1,172 @@
+package blackjack
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CreditLine is a ChipManager that extends credit to a player, in the manner of a
+// casino marker. The player may borrow chips up to the credit limit, either
+// explicitly with Borrow or automatically when a bet needs more chips than they
+// have. Debt is repaid from chips credited to the player, such as winnings, unless
+// automatic repayment is turned off.
+type CreditLine struct {
+	mu        sync.Mutex
+	cm        ChipManager
+	limit     int
+	debt      int
+	autoRepay bool
+}
+
+// CreditOption configures a CreditLine
+type CreditOption func(*CreditLine)
+
+// WithAutoRepay sets whether debt is repaid automatically from chips credited to
+// the player (true by default)
+func WithAutoRepay(autoRepay bool) CreditOption {
+	return func(c *CreditLine) {
+		c.autoRepay = autoRepay
+	}
+}
+
+// NewCreditLine creates a credit line with the given limit around a chip manager
+func NewCreditLine(cm ChipManager, limit int, options ...CreditOption) *CreditLine {
+	c := &CreditLine{cm: cm, limit: limit, autoRepay: true}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WithCreditLine extends credit up to the limit to the player, wrapping their chip
+// manager in a CreditLine. It should come after any WithChipManager option.
+func WithCreditLine(limit int, options ...CreditOption) Option {
+	return func(p *Player) {
+		p.chipManager = NewCreditLine(p.chipManager, limit, options...)
+	}
+}
+
+// CreditLine returns the player's credit line, if their chip manager is one
+func (p *Player) CreditLine() (*CreditLine, bool) {
+	credit, ok := p.chipManager.(*CreditLine)
+	return credit, ok && p.contextChips == nil
+}
+
+// Limit returns the most the player may owe
+func (c *CreditLine) Limit() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.limit
+}
+
+// SetLimit changes the most the player may owe. Lowering the limit below the
+// current debt prevents further borrowing until the debt is repaid.
+func (c *CreditLine) SetLimit(limit int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limit = limit
+}
+
+// Debt returns the amount the player owes
+func (c *CreditLine) Debt() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.debt
+}
+
+// Available returns the amount the player may still borrow
+func (c *CreditLine) Available() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.available()
+}
+
+// Borrow draws a marker for the amount, adding it to the player's chips and debt
+func (c *CreditLine) Borrow(amount int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.borrow(amount)
+}
+
+// Repay pays back the amount of the player's debt from their chips
+func (c *CreditLine) Repay(amount int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if amount <= 0 {
+		return fmt.Errorf("repayment must be positive")
+	}
+	if amount > c.debt {
+		return fmt.Errorf("repayment of %d is more than the debt of %d", amount, c.debt)
+	}
+	if err := c.cm.DeductChips(amount); err != nil {
+		return fmt.Errorf("failed to repay %d: %w", amount, err)
+	}
+	c.debt -= amount
+	return nil
+}
+
+// GetChips returns the player's own chips, not counting available credit
+func (c *CreditLine) GetChips() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cm.GetChips()
+}
+
+// SetChips sets the player's own chips. The debt is unchanged.
+func (c *CreditLine) SetChips(amount int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cm.SetChips(amount)
+}
+
+// AddChips adds chips to the player, first repaying any debt if automatic
+// repayment is on
+func (c *CreditLine) AddChips(amount int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.autoRepay && amount > 0 {
+		repaid := min(amount, c.debt)
+		c.debt -= repaid
+		amount -= repaid
+	}
+	c.cm.AddChips(amount)
+}
+
+// DeductChips removes chips from the player, borrowing any shortfall if there is
+// enough credit available
+func (c *CreditLine) DeductChips(amount int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if shortfall := amount - c.cm.GetChips(); shortfall > 0 {
+		if err := c.borrow(shortfall); err != nil {
+			return err
+		}
+	}
+	return c.cm.DeductChips(amount)
+}
+
+// HasEnoughChips returns true if the player's chips and available credit cover the
+// specified amount
+func (c *CreditLine) HasEnoughChips(amount int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cm.HasEnoughChips(amount) || c.cm.GetChips()+c.available() >= amount
+}
+
+// available returns the amount the player may still borrow
+func (c *CreditLine) available() int {
+	return max(0, c.limit-c.debt)
+}
+
+// borrow adds the amount to the player's chips and debt
+func (c *CreditLine) borrow(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("amount borrowed must be positive")
+	}
+	if amount > c.available() {
+		return fmt.Errorf("insufficient credit: have %d available, need %d", c.available(), amount)
+	}
+	c.cm.AddChips(amount)
+	c.debt += amount
+	return nil
+}
@@ -0,0 +1,138 @@
+package blackjack
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Currency describes the unit that chip amounts are counted in. Chip amounts are
+// always whole numbers of the currency's minor unit (e.g., cents), so that decimal
+// amounts are never subject to floating point rounding.
+type Currency struct {
+	Code     string `json:"code"`     // Code is the currency code, such as "USD" (empty for plain chips)
+	Exponent int    `json:"exponent"` // Exponent is the number of decimal places in the currency's minor unit (e.g., 2 for cents)
+}
+
+var (
+	PlainChips = Currency{}                         // PlainChips are indivisible chips with no currency
+	USD        = Currency{Code: "USD", Exponent: 2} // USD is the US dollar, counted in cents
+	EUR        = Currency{Code: "EUR", Exponent: 2} // EUR is the euro, counted in cents
+	GBP        = Currency{Code: "GBP", Exponent: 2} // GBP is the pound sterling, counted in pence
+	JPY        = Currency{Code: "JPY", Exponent: 0} // JPY is the Japanese yen, which has no minor unit
+)
+
+// WithCurrency sets the currency that the game's chip amounts are counted in
+func WithCurrency(currency Currency) GameOption {
+	return func(g *Game) {
+		g.currency = currency
+	}
+}
+
+// Currency returns the currency that the game's chip amounts are counted in
+func (bg *Game) Currency() Currency {
+	return bg.currency
+}
+
+// Format returns the amount, in minor units, as a decimal string followed by the
+// currency code (e.g., 1250 USD is "12.50 USD")
+func (c Currency) Format(amount int) string {
+	s := c.FormatAmount(amount)
+	if c.Code == "" {
+		return s
+	}
+	return s + " " + c.Code
+}
+
+// FormatAmount returns the amount, in minor units, as a decimal string without the
+// currency code
+func (c Currency) FormatAmount(amount int) string {
+	if c.Exponent <= 0 {
+		return strconv.Itoa(amount)
+	}
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(uint64(absInt(amount)), 10)
+	if len(digits) <= c.Exponent {
+		digits = strings.Repeat("0", c.Exponent-len(digits)+1) + digits
+	}
+	point := len(digits) - c.Exponent
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Parse converts a decimal string (e.g., "12.50") into an amount in minor units.
+// The string may not have more decimal places than the currency allows.
+func (c Currency) Parse(s string) (int, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), c.Code))
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if len(frac) > max(c.Exponent, 0) || (hasPoint && frac == "") {
+		return 0, fmt.Errorf("invalid amount %q: at most %d decimal places are allowed", s, max(c.Exponent, 0))
+	}
+	frac += strings.Repeat("0", max(c.Exponent, 0)-len(frac))
+	amount, err := strconv.Atoi(whole + frac)
+	if err != nil || strings.ContainsAny(frac, "+-") {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	return amount, nil
+}
+
+// RoundingMode determines how a fractional payout is rounded to a whole minor unit
+type RoundingMode int
+
+const (
+	RoundDown     RoundingMode = iota // RoundDown truncates toward zero, in the house's favor for wins
+	RoundHalfUp                       // RoundHalfUp rounds halves away from zero
+	RoundHalfEven                     // RoundHalfEven rounds halves to the nearest even amount (banker's rounding)
+	RoundUp                           // RoundUp rounds away from zero, in the player's favor for wins
+)
+
+// ExactPayoutPolicy pays each hand its bet times the evaluation's multiplier using
+// exact rational arithmetic, rounding any fractional minor unit (such as the half
+// cent from a 3:2 payout on an odd bet) with the configured rounding mode
+type ExactPayoutPolicy struct {
+	Rounding RoundingMode // Rounding is how fractional amounts are rounded
+}
+
+// Payout returns the bet multiplied by the evaluation's payout multiplier, rounded
+// to a whole minor unit
+func (p ExactPayoutPolicy) Payout(hand *Hand, eval Evaluation) int {
+	return exactPayout(hand.Bet(), eval.Multiplier, p.Rounding)
+}
+
+// Round rounds an exact amount to a whole number using the rounding mode
+func (m RoundingMode) Round(amount *big.Rat) int {
+	num := new(big.Int).Set(amount.Num())
+	den := amount.Denom()
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return int(quo.Int64())
+	}
+
+	away := int64(num.Sign()) // away is the step away from zero
+	switch m {
+	case RoundUp:
+		quo.Add(quo, big.NewInt(away))
+	case RoundHalfUp, RoundHalfEven:
+		// Compare twice the remainder against the denominator to find the nearer whole amount
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+		switch cmp := twice.Cmp(den); {
+		case cmp > 0:
+			quo.Add(quo, big.NewInt(away))
+		case cmp == 0 && (m == RoundHalfUp || quo.Bit(0) == 1):
+			quo.Add(quo, big.NewInt(away))
+		}
+	}
+	return int(quo.Int64())
+}
+
+// absInt returns the absolute value of n
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
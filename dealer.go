@@ -1,21 +1,23 @@
 package blackjack
 
 import (
-	"fmt"
-
 	"github.com/rbrabson/cards"
 )
 
 // Dealer represents the blackjack dealer
 type Dealer struct {
-	hand *Hand // hand is the dealer's hand
+	hand             *Hand // hand is the dealer's hand
+	holeCardRevealed bool  // holeCardRevealed is whether the hole card has been turned face up
+	game             *Game // game is the game the dealer is dealing, if any
 }
 
 // NewDealer creates a new dealer
 func NewDealer() *Dealer {
-	return &Dealer{
+	d := &Dealer{
 		hand: NewDealerHand(),
 	}
+	d.hand.dealer = d
+	return d
 }
 
 // Hand returns the dealer's hand
@@ -26,23 +28,33 @@ func (d *Dealer) Hand() *Hand {
 // Hit adds a card to the dealer's hand
 func (d *Dealer) Hit(card cards.Card) {
 	d.hand.AddCardWithAction(card, ActionHit, "dealer hit")
+	d.logAction()
 }
 
 // DealCard adds a card to the dealer's hand as part of the initial deal
 func (d *Dealer) DealCard(card cards.Card) {
 	d.hand.AddCardWithAction(card, ActionDeal, "initial deal")
+	d.logAction()
 }
 
 // Stand marks the dealer as standing
 func (d *Dealer) Stand() {
 	d.hand.RecordAction(ActionStand, "dealer stands")
+	d.logAction()
 	d.hand.isStood = true
 	d.hand.isActive = false
 }
 
-// ShouldHit returns true if the dealer should hit according to standard blackjack rules
-// Dealer hits on 16 or less, stands on 17 or more (including soft 17)
+// ShouldHit returns true if the dealer should hit according to standard blackjack rules.
+// The dealer hits on 16 or less and stands on 17 or more, hitting a soft 17 unless
+// the table rules say to stand.
 func (d *Dealer) ShouldHit() bool {
+	return d.shouldHit(d.game != nil && d.game.rules.StandSoft17)
+}
+
+// shouldHit returns true if the dealer should hit, standing on soft 17 if standSoft17
+// is set
+func (d *Dealer) shouldHit(standSoft17 bool) bool {
 	value := d.hand.Value()
 
 	switch {
@@ -52,9 +64,9 @@ func (d *Dealer) ShouldHit() bool {
 	// Stand on hard 17 or higher
 	case value >= 17 && !d.hand.IsSoft():
 		return false
-	// Hit on soft 17 (house rule - can be changed)
+	// Hit on soft 17 unless the table stands on it
 	case value == 17 && d.hand.IsSoft():
-		return true
+		return !standSoft17
 	// Stand on soft 18 or higher
 	case value >= 18:
 		return false
@@ -66,11 +78,32 @@ func (d *Dealer) ShouldHit() bool {
 }
 
 // ShowFirstCard returns the dealer's first card (face up)
+//
+// Deprecated: use UpCard, which does not panic if the dealer has no cards.
 func (d *Dealer) ShowFirstCard() cards.Card {
-	if d.hand.Count() == 0 {
+	card, ok := d.UpCard()
+	if !ok {
 		panic("dealer has no cards")
 	}
-	return d.hand.Cards()[0]
+	return card
+}
+
+// UpCard returns the dealer's face up card. The boolean is false if the dealer has
+// not been dealt a card.
+func (d *Dealer) UpCard() (cards.Card, bool) {
+	if d.hand.Count() < 1 {
+		return cards.Card{}, false
+	}
+	return d.hand.cards[0], true
+}
+
+// HoleCard returns the dealer's face down card, whether or not it has been
+// revealed. The boolean is false if the dealer has not been dealt a hole card.
+func (d *Dealer) HoleCard() (cards.Card, bool) {
+	if d.hand.Count() < 2 {
+		return cards.Card{}, false
+	}
+	return d.hand.cards[1], true
 }
 
 // HasBlackjack returns true if dealer has blackjack
@@ -88,22 +121,44 @@ func (d *Dealer) Value() int {
 	return d.hand.Value()
 }
 
+// VisibleValue returns the value of the dealer's cards that are face up: the
+// whole hand once the hole card is revealed, and otherwise every card but the hole card
+func (d *Dealer) VisibleValue() int {
+	if d.holeCardRevealed {
+		return d.hand.Value()
+	}
+	return d.hand.VisibleValue()
+}
+
 // ClearHand clears the dealer's hand for a new round
 func (d *Dealer) ClearHand() {
 	d.hand.Clear()
+	d.holeCardRevealed = false
 }
 
 // String returns a string representation of the dealer with both cards showing
 func (d *Dealer) String() string {
-	return fmt.Sprintf("Dealer: %s", d.hand.String())
+	return Message(MsgDealer, d.hand.String())
 }
 
 // StringHidden returns a string representation of the dealer with hole card hidden
 func (d *Dealer) StringHidden() string {
-	return fmt.Sprintf("Dealer: %s", d.hand.StringHidden())
+	return Message(MsgDealer, d.hand.StringHidden())
 }
 
-// RevealHoleCard shows the dealer's full hand
+// RevealHoleCard turns the hole card face up and returns the dealer's full hand.
+// The first time a hole card is turned up, the game emits EventHoleCardRevealed.
 func (d *Dealer) RevealHoleCard() string {
+	if !d.holeCardRevealed {
+		d.holeCardRevealed = true
+		if card, ok := d.HoleCard(); ok && d.game != nil {
+			d.game.holeCardRevealed(card)
+		}
+	}
 	return d.String()
 }
+
+// IsHoleCardRevealed returns true if the dealer's hole card has been turned face up
+func (d *Dealer) IsHoleCardRevealed() bool {
+	return d.holeCardRevealed
+}
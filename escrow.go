@@ -0,0 +1,203 @@
+package blackjack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// EscrowChipManager is a ChipManager that holds bets in escrow. A bet is reserved
+// when it is placed, taking it out of the available chips, and is only committed
+// when the hand is settled. If the round is aborted, reserved bets are released
+// back to the player. Chip managers backed by an external wallet can implement
+// this to avoid debiting the wallet for a round that never completes.
+type EscrowChipManager interface {
+	ChipManager
+	Reserve(amount int) error // Reserve moves the amount from the available chips into escrow
+	Commit(amount int) error  // Commit finalizes the amount held in escrow as spent
+	Release(amount int) error // Release returns the amount held in escrow to the available chips
+	Reserved() int            // Reserved returns the amount held in escrow
+}
+
+// Reserve moves the amount from the available chips into escrow
+func (c *DefaultChipManager) Reserve(amount int) error {
+	if amount > c.chips {
+		return fmt.Errorf("insufficient chips: have %d, need %d", c.chips, amount)
+	}
+	c.chips -= amount
+	c.reserved += amount
+	return nil
+}
+
+// Commit finalizes the amount held in escrow as spent
+func (c *DefaultChipManager) Commit(amount int) error {
+	if amount > c.reserved {
+		return fmt.Errorf("cannot commit %d chips: only %d are reserved", amount, c.reserved)
+	}
+	c.reserved -= amount
+	return nil
+}
+
+// Release returns the amount held in escrow to the available chips
+func (c *DefaultChipManager) Release(amount int) error {
+	if amount > c.reserved {
+		return fmt.Errorf("cannot release %d chips: only %d are reserved", amount, c.reserved)
+	}
+	c.reserved -= amount
+	c.chips += amount
+	return nil
+}
+
+// Reserved returns the amount held in escrow
+func (c *DefaultChipManager) Reserved() int {
+	return c.reserved
+}
+
+// reserveChips takes a bet from the player's available chips, holding it in escrow
+// if the chip manager supports it, and reports the change
+func (p *Player) reserveChips(ctx context.Context, amount int, reason ChipReason) error {
+	if escrow, ok := p.escrow(); ok {
+		if err := escrow.Reserve(amount); err != nil {
+			return err
+		}
+		p.chipsChanged(-amount, reason)
+		return nil
+	}
+	return p.debitChips(ctx, amount, reason)
+}
+
+// commitChips finalizes a bet held in escrow once its hand has been settled. Chip
+// managers without escrow deducted the bet when it was placed, so there is nothing
+// more to do.
+func (p *Player) commitChips(amount int) error {
+	escrow, ok := p.escrow()
+	if !ok || amount <= 0 {
+		return nil
+	}
+	if err := escrow.Commit(amount); err != nil {
+		return fmt.Errorf("failed to commit bet of %d for %s: %w", amount, p.name, err)
+	}
+	return nil
+}
+
+// releaseChips returns a bet to the player's available chips, releasing it from
+// escrow if the chip manager supports it, and reports the change
+func (p *Player) releaseChips(ctx context.Context, amount int, reason ChipReason) error {
+	if amount <= 0 {
+		return nil
+	}
+	escrow, ok := p.escrow()
+	if !ok {
+		if err := p.creditChips(ctx, amount, reason); err != nil {
+			return fmt.Errorf("failed to return bet of %d to %s: %w", amount, p.name, err)
+		}
+		return nil
+	}
+	if err := escrow.Release(amount); err != nil {
+		return fmt.Errorf("failed to release bet of %d for %s: %w", amount, p.name, err)
+	}
+	p.chipsChanged(amount, reason)
+	return nil
+}
+
+// escrow returns the player's chip manager if it holds bets in escrow
+func (p *Player) escrow() (EscrowChipManager, bool) {
+	if p.contextChips != nil {
+		return nil, false
+	}
+	escrow, ok := p.chipManager.(EscrowChipManager)
+	return escrow, ok
+}
+
+// outstandingBets returns the total of the player's bets that have not been
+// settled. Surrendered hands are settled when they are surrendered.
+func (p *Player) outstandingBets() int {
+	total := 0
+	for _, hand := range p.hands {
+		if !hand.isSurrendered && !hand.IsSettled() {
+			total += hand.bets.Total()
+		}
+	}
+	return total
+}
+
+// leaveRound clears the bets of a player leaving the round in progress. Bets placed
+// before the cards are dealt are returned, while the bets on hands that were dealt
+// are forfeited.
+func (bg *Game) leaveRound(player *Player) error {
+	bets := player.outstandingBets()
+	if !bg.roundInProgress() || bets == 0 {
+		return nil
+	}
+	if bg.phase == PhaseBetting {
+		if err := player.releaseChips(context.Background(), bets, ChipReasonRefund); err != nil {
+			return err
+		}
+	} else if err := player.commitChips(bets); err != nil {
+		return err
+	}
+	for _, hand := range player.hands {
+		if !hand.isSurrendered && !hand.IsSettled() {
+			hand.bets = BetComponents{}
+		}
+	}
+	return nil
+}
+
+// AbortRound abandons the round in progress, returning every unsettled bet to its
+// player and clearing all hands. The round is not added to the history. It does
+// nothing if no round is in progress. If a bet can't be returned, the round is
+// left in progress with the bets that weren't returned, so that it can be aborted
+// again, and the chip manager's error is returned.
+func (bg *Game) AbortRound() error {
+	if !bg.roundInProgress() {
+		return nil
+	}
+	if err := bg.refundBets(); err != nil {
+		bg.snapshot()
+		return err
+	}
+	bg.phase = PhaseWaiting
+	bg.record = nil
+	bg.dealer.ClearHand()
+	for _, player := range bg.players {
+		player.ClearHands()
+		player.SetActive(true)
+		player.waiting = false
+	}
+	bg.log().Info("round aborted", "number", bg.round)
+	bg.emit(Event{Type: EventRoundAborted, Details: fmt.Sprintf("round %d", bg.round)})
+	bg.endRoundSpan()
+	bg.snapshot()
+	return nil
+}
+
+// abortAfter abandons the round after it failed with err, returning err along with
+// any error from abandoning it
+func (bg *Game) abortAfter(err error) error {
+	if abortErr := bg.AbortRound(); abortErr != nil {
+		return errors.Join(err, abortErr)
+	}
+	return err
+}
+
+// refundBets returns every unsettled bet of the round in progress to its player. A
+// player whose bets can't be returned keeps them on their hands.
+func (bg *Game) refundBets() error {
+	if !bg.roundInProgress() {
+		return nil
+	}
+	var errs []error
+	for _, player := range bg.players {
+		if err := player.releaseChips(context.Background(), player.outstandingBets(), ChipReasonRefund); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, hand := range player.hands {
+			if !hand.isSurrendered && !hand.IsSettled() {
+				hand.bets = BetComponents{}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
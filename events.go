@@ -0,0 +1,157 @@
+package blackjack
+
+import (
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/rbrabson/cards"
+)
+
+// EventType identifies the kind of event emitted by a game
+type EventType string
+
+const (
+	EventRoundStarted     EventType = "round_started"      // EventRoundStarted is emitted when a new round is started
+	EventCardDealt        EventType = "card_dealt"         // EventCardDealt is emitted when a card is dealt to a player or the dealer
+	EventHoleCardRevealed EventType = "hole_card_revealed" // EventHoleCardRevealed is emitted when the dealer turns the hole card face up
+	EventCardBurned       EventType = "card_burned"        // EventCardBurned is emitted when a card is burned from a freshly shuffled shoe
+	EventCutCardReached   EventType = "cut_card_reached"   // EventCutCardReached is emitted when the cut card comes out, so the shoe is shuffled after the round
+	EventShoeShuffled     EventType = "shoe_shuffled"      // EventShoeShuffled is emitted when the game reshuffles the shoe
+	EventHandBusted       EventType = "hand_busted"        // EventHandBusted is emitted when a player's hand busts
+	EventTurnEnded        EventType = "turn_ended"         // EventTurnEnded is emitted when a player has no more hands to play
+	EventChipsChanged     EventType = "chips_changed"      // EventChipsChanged is emitted when a player's chip balance changes
+	EventPlayerJoined     EventType = "player_joined"      // EventPlayerJoined is emitted when a player is added to the game
+	EventRoundCompleted   EventType = "round_completed"    // EventRoundCompleted is emitted when a round has been settled
+	EventRoundAborted     EventType = "round_aborted"      // EventRoundAborted is emitted when a round is abandoned and its bets are refunded
+	EventBonusClaimed     EventType = "bonus_claimed"      // EventBonusClaimed is emitted when a player claims bonus chips
+	EventPlayerRenamed    EventType = "player_renamed"     // EventPlayerRenamed is emitted when a player's name is changed
+)
+
+// Event describes something that happened in a game
+type Event struct {
+	Type      EventType    `json:"type"`
+	GameID    string       `json:"game_id"`
+	RoundID   int64        `json:"round_id"`
+	Player    string       `json:"player,omitempty"`  // Player is the name of the player involved, if any (empty for the dealer)
+	Hand      int          `json:"hand"`              // Hand is the index of the player's hand involved
+	Card      *cards.Card  `json:"card,omitempty"`    // Card is the card involved, if any (nil for the dealer's hole card until it is revealed)
+	Amount    int          `json:"amount,omitempty"`  // Amount is the change in the player's chips, for chip events
+	Balance   int          `json:"balance,omitempty"` // Balance is the player's chips after the change, for chip events
+	Reason    ChipReason   `json:"reason,omitempty"`  // Reason is why the player's chips changed, for chip events
+	Details   string       `json:"details,omitempty"`
+	Record    *RoundRecord `json:"record,omitempty"` // Record is the completed round, for round completion events
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// Listener receives events emitted by a game
+type Listener interface {
+	OnEvent(event Event)
+}
+
+// ListenerFunc adapts an ordinary function to the Listener interface
+type ListenerFunc func(event Event)
+
+// OnEvent calls f(event)
+func (f ListenerFunc) OnEvent(event Event) {
+	f(event)
+}
+
+// WithListener registers a listener that receives the game's events.
+func WithListener(listener Listener) GameOption {
+	return func(g *Game) {
+		g.listeners = append(g.listeners, listener)
+	}
+}
+
+// AddListener registers a listener that receives the game's events
+func (bg *Game) AddListener(listener Listener) {
+	bg.listeners = append(bg.listeners, listener)
+}
+
+// EventBuffer is the number of events a channel returned by Events holds for a
+// consumer that has fallen behind
+const EventBuffer = 256
+
+// eventChannel is a listener that sends the game's events to a buffered channel
+type eventChannel struct {
+	events  chan Event
+	dropped atomic.Int64 // dropped is the number of events dropped because the channel was full
+}
+
+// OnEvent sends the event to the channel, dropping it if the channel is full
+func (c *eventChannel) OnEvent(event Event) {
+	select {
+	case c.events <- event:
+	default:
+		c.dropped.Add(1)
+	}
+}
+
+// Events returns a channel that receives the game's events, for consumers that
+// select on channels rather than implementing Listener. Each call returns a new
+// channel holding up to EventBuffer events. The game never waits for a consumer:
+// an event emitted while the channel is full is dropped, and counted by
+// DroppedEvents. Like AddListener, Events must not be called while the game is in
+// use by another goroutine.
+func (bg *Game) Events() <-chan Event {
+	c := &eventChannel{events: make(chan Event, EventBuffer)}
+	bg.listeners = append(bg.listeners, c)
+	return c.events
+}
+
+// StopEvents stops sending the game's events to a channel returned by Events and
+// closes it once the events already sent have been received
+func (bg *Game) StopEvents(events <-chan Event) {
+	bg.listeners = slices.DeleteFunc(bg.listeners, func(listener Listener) bool {
+		c, ok := listener.(*eventChannel)
+		if ok && c.events == events {
+			close(c.events)
+			return true
+		}
+		return false
+	})
+}
+
+// DroppedEvents returns the number of events not sent to a channel returned by
+// Events because it was full
+func (bg *Game) DroppedEvents(events <-chan Event) int {
+	for _, listener := range bg.listeners {
+		if c, ok := listener.(*eventChannel); ok && c.events == events {
+			return int(c.dropped.Load())
+		}
+	}
+	return 0
+}
+
+// emit fills in the game and round identifiers and delivers the event to all listeners
+func (bg *Game) emit(event Event) {
+	event.GameID = bg.id
+	event.RoundID = bg.roundID
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	for _, listener := range bg.listeners {
+		listener.OnEvent(event)
+	}
+}
+
+// cardDealt emits an event for a card dealt to one of the player's hands, or to the
+// dealer if player is nil. The dealer's hole card is reported without the card.
+func (bg *Game) cardDealt(player *Player, hand int, card *cards.Card, details string) {
+	event := Event{Type: EventCardDealt, Hand: hand, Details: details}
+	if card != nil {
+		// Listeners may hold on to the event, so it gets its own copy of the card
+		dealt := *card
+		event.Card = &dealt
+	}
+	if player != nil {
+		event.Player = player.Name()
+	}
+	bg.emit(event)
+}
+
+// holeCardRevealed emits an event for the dealer's hole card being turned face up
+func (bg *Game) holeCardRevealed(card cards.Card) {
+	bg.emit(Event{Type: EventHoleCardRevealed, Card: &card, Details: "hole card revealed"})
+}
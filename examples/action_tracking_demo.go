@@ -80,11 +80,13 @@ func demonstrateActionTracking() {
 	fmt.Println("\n💰 Double Down Example:")
 	player2 := blackjack.NewPlayer("Bob", blackjack.WithChips(1000))
 	hand2 := player2.CurrentHand()
-	hand2.DealCard(cards.Card{Suit: cards.Hearts, Rank: cards.Two})
+	hand2.DealCard(cards.Card{Suit: cards.Hearts, Rank: cards.Five})
+	hand2.DealCard(cards.Card{Suit: cards.Diamonds, Rank: cards.Six})
 	hand2.PlaceBet(50)
 
-	hand2.DoubleDown()
-	hand2.DoubleDownHit(cards.Card{Suit: cards.Clubs, Rank: cards.Ten})
+	if err := hand2.DoubleDownWith(cards.Card{Suit: cards.Clubs, Rank: cards.Ten}); err != nil {
+		fmt.Printf("Bob can't double down: %v\n", err)
+	}
 	fmt.Printf("Bob's Hand: %s\n", player2.CurrentHand().String())
 	fmt.Printf("Bob's Actions: %s\n", player2.CurrentHand().ActionSummary())
 
@@ -96,7 +98,9 @@ func demonstrateActionTracking() {
 	hand3.DealCard(cards.Card{Suit: cards.Hearts, Rank: cards.Six})
 	hand3.PlaceBet(100)
 
-	hand3.Surrender()
+	if err := hand3.Surrender(); err != nil {
+		fmt.Printf("Charlie can't surrender: %v\n", err)
+	}
 	fmt.Printf("Charlie's Hand: %s\n", player3.CurrentHand().String())
 	fmt.Printf("Charlie's Actions: %s\n", player3.CurrentHand().ActionSummary())
 
@@ -67,6 +67,12 @@ func main() {
 		fmt.Printf("- %s: %d chips\n", player.Name(), player.Chips())
 	}
 
+	// Bets may only be placed once a round has started
+	if err := game.StartNewRound(); err != nil {
+		fmt.Printf("Error starting round: %v\n", err)
+		return
+	}
+
 	// Demonstrate the daily limit feature
 	bob := game.GetPlayer("Bob")
 
@@ -79,7 +85,9 @@ func main() {
 		fmt.Printf("Error placing 300 chip bet: %v\n", err)
 	} else {
 		fmt.Printf("Successfully placed 300 chip bet. Remaining chips: %d\n", bob.Chips())
-		bobHand.LoseBet() // Simulate losing the bet
+		if err := bobHand.LoseBet(); err != nil { // Simulate losing the bet
+			fmt.Printf("Error settling the bet: %v\n", err)
+		}
 	}
 
 	// This should fail (exceeds daily limit)
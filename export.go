@@ -0,0 +1,166 @@
+package blackjack
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/rbrabson/cards"
+)
+
+// HandHistoryEntry is a single completed hand as written by a HandHistoryExporter
+type HandHistoryEntry struct {
+	GameID      string       `json:"game_id"`
+	RoundID     int64        `json:"round_id"`
+	Round       int          `json:"round"`
+	Player      string       `json:"player"`
+	Hand        int          `json:"hand"`
+	Spot        int          `json:"spot"`
+	Cards       []cards.Card `json:"cards"`
+	Bet         int          `json:"bet"`
+	Result      GameResult   `json:"result"`
+	Payout      int          `json:"payout"` // Payout is the net amount won (negative for a loss)
+	DealerCards []cards.Card `json:"dealer_cards"`
+	Actions     []Action     `json:"actions"`
+	SettledAt   time.Time    `json:"settled_at"`
+}
+
+// HandHistoryExporter writes every completed hand as newline-delimited JSON. It
+// can be registered as a game listener to export hands as rounds complete.
+type HandHistoryExporter struct {
+	enc *json.Encoder
+	err error
+}
+
+// NewHandHistoryExporter creates an exporter that writes to w
+func NewHandHistoryExporter(w io.Writer) *HandHistoryExporter {
+	return &HandHistoryExporter{enc: json.NewEncoder(w)}
+}
+
+// OnEvent exports the hands of each completed round
+func (e *HandHistoryExporter) OnEvent(event Event) {
+	if event.Type != EventRoundCompleted || event.Record == nil {
+		return
+	}
+	if err := e.ExportRound(event.GameID, event.Record); err != nil && e.err == nil {
+		e.err = err
+	}
+}
+
+// Err returns the first error encountered while exporting rounds from game events
+func (e *HandHistoryExporter) Err() error {
+	return e.err
+}
+
+// ExportRound writes one entry for every hand in the round
+func (e *HandHistoryExporter) ExportRound(gameID string, record *RoundRecord) error {
+	for _, seat := range record.Seats {
+		for i, hand := range seat.Hands {
+			entry := HandHistoryEntry{
+				GameID:      gameID,
+				RoundID:     record.ID,
+				Round:       record.Number,
+				Player:      seat.Name,
+				Hand:        i,
+				Spot:        hand.Spot,
+				Cards:       hand.Cards,
+				Bet:         hand.Bet,
+				Result:      hand.Result,
+				Payout:      hand.Winnings,
+				DealerCards: record.DealerCards,
+				Actions:     hand.Actions,
+				SettledAt:   record.EndedAt,
+			}
+			if err := e.enc.Encode(entry); err != nil {
+				return fmt.Errorf("failed to export hand %d of %s in round %d: %w", i+1, seat.Name, record.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ExportHistory writes every hand in the game's round history
+func (e *HandHistoryExporter) ExportHistory(game *Game) error {
+	for _, record := range game.History() {
+		if err := e.ExportRound(game.ID(), record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvHeader is the header row written by a RoundResultsCSVExporter
+var csvHeader = []string{"round", "player", "hand", "bet", "result", "net", "balance"}
+
+// RoundResultsCSVExporter writes the result of every completed hand as CSV, one
+// row per hand. It can be registered as a game listener to export hands as
+// rounds complete.
+type RoundResultsCSVExporter struct {
+	w             *csv.Writer
+	headerWritten bool
+	err           error
+}
+
+// NewRoundResultsCSVExporter creates an exporter that writes to w
+func NewRoundResultsCSVExporter(w io.Writer) *RoundResultsCSVExporter {
+	return &RoundResultsCSVExporter{w: csv.NewWriter(w)}
+}
+
+// OnEvent exports the results of each completed round
+func (e *RoundResultsCSVExporter) OnEvent(event Event) {
+	if event.Type != EventRoundCompleted || event.Record == nil {
+		return
+	}
+	if err := e.ExportRound(event.Record); err != nil && e.err == nil {
+		e.err = err
+	}
+}
+
+// Err returns the first error encountered while exporting rounds from game events
+func (e *RoundResultsCSVExporter) Err() error {
+	return e.err
+}
+
+// ExportRound writes one row for every hand in the round. The balance column is
+// the player's running chip balance after each hand is settled.
+func (e *RoundResultsCSVExporter) ExportRound(record *RoundRecord) error {
+	if !e.headerWritten {
+		if err := e.w.Write(csvHeader); err != nil {
+			return fmt.Errorf("failed to write csv header: %w", err)
+		}
+		e.headerWritten = true
+	}
+	for _, seat := range record.Seats {
+		balance := seat.Chips
+		for i, hand := range seat.Hands {
+			balance += hand.Winnings
+			row := []string{
+				strconv.Itoa(record.Number),
+				seat.Name,
+				strconv.Itoa(i + 1),
+				strconv.Itoa(hand.Bet),
+				hand.Result.String(),
+				strconv.Itoa(hand.Winnings),
+				strconv.Itoa(balance),
+			}
+			if err := e.w.Write(row); err != nil {
+				return fmt.Errorf("failed to export hand %d of %s in round %d: %w", i+1, seat.Name, record.Number, err)
+			}
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ExportHistory writes every hand in the game's round history
+func (e *RoundResultsCSVExporter) ExportHistory(game *Game) error {
+	for _, record := range game.History() {
+		if err := e.ExportRound(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
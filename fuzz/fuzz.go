@@ -0,0 +1,395 @@
+// Package fuzz drives random sequences of operations against a game, legal and
+// illegal alike, checking the engine's invariants after every step: no player has
+// negative chips or gains or loses chips the round doesn't account for, no card is
+// dealt that the rules don't call for, and the round's phase agrees with the
+// state of the table.
+//
+// Run decodes the operations from a byte slice, so it can be used as the body of a
+// native Go fuzz test:
+//
+//	func FuzzGame(f *testing.F) {
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			if err := fuzz.Run(data); err != nil {
+//				t.Fatal(err)
+//			}
+//		})
+//	}
+//
+// RunSeed plays a reproducible random sequence for property-style testing.
+package fuzz
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/odds"
+	"github.com/rbrabson/cards"
+)
+
+const (
+	numDecks     = 2   // numDecks is the number of decks in the shoe, small so that it runs out often
+	startChips   = 200 // startChips are the chips each player is seated with
+	bytesPerStep = 3   // bytesPerStep is about the number of bytes of input each operation consumes
+)
+
+var (
+	names   = []string{"Ann", "Ben", "Cy"}                    // names are the players that may be seated
+	amounts = []int{-5, 0, 1, 2, 5, 10, 25, 99, 1000, 100000} // amounts are the bets and rebuys that may be tried
+	actions = []blackjack.ActionType{                         // actions are the actions that may be tried, the last few never legal
+		blackjack.ActionHit, blackjack.ActionStand, blackjack.ActionDouble, blackjack.ActionSplit, blackjack.ActionSurrender,
+		blackjack.ActionDeal, blackjack.ActionBust, "fly",
+	}
+)
+
+// Failure describes an invariant broken by an operation
+type Failure struct {
+	Step   int    // Step is the number of the operation that broke the invariant, starting at 1
+	Op     string // Op describes the operation
+	Reason string // Reason describes the invariant that was broken
+}
+
+// Error returns a description of the failure
+func (f *Failure) Error() string {
+	return fmt.Sprintf("step %d (%s): %s", f.Step, f.Op, f.Reason)
+}
+
+// runner plays the operations decoded from the input against a game
+type runner struct {
+	data       []byte
+	game       *blackjack.Game
+	violations []blackjack.ChipViolation
+	step       int
+	op         string
+	round      int // round is the last round number seen, which must never go down
+}
+
+// Run plays the operations decoded from data against a new game, returning the
+// first invariant broken, or nil once the data runs out. The first bytes choose
+// the shoe's seed and the table's rules. Any panic is returned as a failure.
+func Run(data []byte) (err error) {
+	r := &runner{data: data}
+	defer func() {
+		if p := recover(); p != nil {
+			err = &Failure{Step: r.step, Op: r.op, Reason: fmt.Sprintf("panic: %v", p)}
+		}
+	}()
+
+	seed := binary.LittleEndian.Uint64(append(r.take(8), make([]byte, 8)...))
+	r.game = blackjack.New(numDecks, append(r.options(r.rules()), blackjack.WithSeed(seed))...)
+	for _, name := range names[:2] {
+		r.game.AddPlayer(name, blackjack.WithChips(startChips))
+	}
+
+	for r.step = 1; len(r.data) > 0; r.step++ {
+		if err := r.play(); err != nil {
+			return &Failure{Step: r.step, Op: r.op, Reason: err.Error()}
+		}
+		if err := r.check(); err != nil {
+			return &Failure{Step: r.step, Op: r.op, Reason: err.Error()}
+		}
+	}
+	return nil
+}
+
+// RunSeed plays a random sequence of about steps operations, generated from the
+// seed so that a failure can be reproduced
+func RunSeed(seed uint64, steps int) error {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	data := make([]byte, 16+steps*bytesPerStep)
+	for i := range data {
+		data[i] = byte(rng.Uint32())
+	}
+	return Run(data)
+}
+
+// take returns the next n bytes of input, or fewer if the input runs out
+func (r *runner) take(n int) []byte {
+	n = min(n, len(r.data))
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+// next returns a number from 0 to n-1 chosen by the next byte of input
+func (r *runner) next(n int) int {
+	b := r.take(1)
+	if len(b) == 0 {
+		return 0
+	}
+	return int(b[0]) % n
+}
+
+// rules returns table rules chosen by the next two bytes of input
+func (r *runner) rules() blackjack.Rules {
+	choice := r.next(256)
+	rules := blackjack.DefaultRules()
+	rules.StandSoft17 = choice&1 != 0
+	rules.DealerPeek = choice&2 == 0
+	rules.NoSurrender = choice&4 != 0
+	rules.NoDoubleAfterSplit = choice&8 != 0
+	rules.MaxSplitHands = 2 + choice>>4&3
+	if choice&64 != 0 {
+		rules.CharlieCards = 5
+	}
+	if choice&128 != 0 {
+		rules.BlackjackPayout = blackjack.SixToFive
+	}
+	choice = r.next(256)
+	rules.ResplitAces = choice&1 != 0
+	rules.HitSplitAces = choice&2 != 0
+	return rules
+}
+
+// options returns the options for a game with the rules that discard its log and
+// check that chips are conserved
+func (r *runner) options(rules blackjack.Rules) []blackjack.GameOption {
+	return []blackjack.GameOption{
+		blackjack.WithLogger(slog.New(slog.DiscardHandler)),
+		blackjack.WithRules(rules),
+		blackjack.WithChipChecks(func(v blackjack.ChipViolation) { r.violations = append(r.violations, v) }),
+	}
+}
+
+// name returns a player name chosen by the next byte of input, which may be a
+// player who isn't seated
+func (r *runner) name() string {
+	return names[r.next(len(names))]
+}
+
+// play performs the next operation, returning an error if an operation that must
+// succeed failed or an operation that must fail succeeded
+func (r *runner) play() error {
+	game := r.game
+	phase := game.Phase()
+	switch op := r.next(16); op {
+	case 0, 1:
+		r.op = "start"
+		if err := game.StartNewRound(); err != nil {
+			return fmt.Errorf("failed to start a round: %w", err)
+		}
+	case 2, 3, 4:
+		name, spot, amount := r.name(), r.next(4), amounts[r.next(len(amounts))]
+		r.op = fmt.Sprintf("bet %s %d on spot %d", name, amount, spot+1)
+		if err := game.PlaceBet(name, spot, amount); err == nil && (phase != blackjack.PhaseBetting || amount <= 0) {
+			return fmt.Errorf("bet accepted during the %s phase", phase)
+		}
+	case 5:
+		r.op = "deal"
+		if err := game.DealInitialCards(); err == nil && phase != blackjack.PhaseBetting {
+			return fmt.Errorf("cards dealt during the %s phase", phase)
+		}
+	case 6, 7, 8, 9:
+		player := game.GetActivePlayer()
+		if player == nil {
+			r.op = "act with no player to act"
+			return nil
+		}
+		action := actions[r.next(5)]
+		r.op = fmt.Sprintf("%s %s", action, player.Name())
+		_, err := game.Act(player.Name(), action)
+		if err != nil && phase == blackjack.PhasePlayerTurns && (action == blackjack.ActionHit || action == blackjack.ActionStand) {
+			return fmt.Errorf("the player to act could not %s: %w", action, err)
+		}
+	case 10:
+		name, action := r.name(), actions[r.next(len(actions))]
+		r.op = fmt.Sprintf("%s %s", action, name)
+		_, err := game.Act(name, action)
+		if err == nil && (phase != blackjack.PhasePlayerTurns || !slices.Contains(actions[:5], action)) {
+			return fmt.Errorf("%s accepted during the %s phase", action, phase)
+		}
+	case 11:
+		r.op = "settle"
+		if _, err := game.SettleIfFinished(); err != nil {
+			return fmt.Errorf("failed to settle: %w", err)
+		}
+	case 12:
+		r.op = "abort"
+		if err := game.AbortRound(); err != nil {
+			return fmt.Errorf("failed to abort: %w", err)
+		}
+	case 13:
+		name := r.name()
+		if game.GetPlayer(name) == nil {
+			r.op = "join " + name
+			game.AddPlayer(name, blackjack.WithChips(startChips))
+		} else {
+			r.op = "leave " + name
+			if err := game.RemovePlayer(name); err != nil {
+				return fmt.Errorf("seated player %s could not leave: %w", name, err)
+			}
+		}
+	case 14:
+		name, amount := r.name(), amounts[r.next(len(amounts))]
+		if player := game.GetPlayer(name); player != nil && amount > 0 {
+			r.op = fmt.Sprintf("add %d chips for %s", amount, name)
+			player.AddChips(amount)
+		} else {
+			spots := r.next(5)
+			r.op = fmt.Sprintf("%s plays %d spots", name, spots)
+			_ = game.SetPlayerSpots(name, spots)
+		}
+	case 15:
+		r.op = "save and load"
+		return r.reload()
+	}
+	return nil
+}
+
+// reload saves the game and loads it again, checking that the table's state is
+// unchanged
+func (r *runner) reload() error {
+	var buf bytes.Buffer
+	if err := r.game.Save(&buf); err != nil {
+		return err
+	}
+	game, err := blackjack.Load(&buf, r.options(r.game.Rules())...)
+	if err != nil {
+		return err
+	}
+	before, err := json.Marshal(r.game.State())
+	if err != nil {
+		return err
+	}
+	after, err := json.Marshal(game.State())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(before, after) {
+		return fmt.Errorf("the loaded game's state differs from the saved game's:\n%s\n%s", before, after)
+	}
+	r.game = game
+	return nil
+}
+
+// check verifies the game's invariants after an operation
+func (r *runner) check() error {
+	game := r.game
+	if len(r.violations) > 0 {
+		return fmt.Errorf("chips not conserved: %s", r.violations[0])
+	}
+	if game.Round() < r.round {
+		return fmt.Errorf("the round number went down from %d to %d", r.round, game.Round())
+	}
+	r.round = game.Round()
+
+	phase := game.Phase()
+	dealt := make(map[cards.Card]int)
+	dealer := game.Dealer().Hand().Cards()
+	for _, card := range dealer {
+		dealt[card]++
+	}
+	for _, player := range game.Players() {
+		if player.Chips() < 0 {
+			return fmt.Errorf("%s has %d chips", player.Name(), player.Chips())
+		}
+		spots := make(map[int]int)
+		for _, hand := range player.Hands() {
+			spots[hand.Spot()]++
+			for _, card := range hand.Cards() {
+				dealt[card]++
+			}
+			if err := checkHand(player, hand, phase, game.Rules()); err != nil {
+				return err
+			}
+		}
+		for spot, hands := range spots {
+			if hands > blackjack.MaxHandsPerSpot {
+				return fmt.Errorf("%s has %d hands on spot %d", player.Name(), hands, spot+1)
+			}
+		}
+	}
+
+	// Cards held plus cards left in the shoe may not exceed the cards in the decks
+	remaining := game.Shoe().RankCounts()
+	for _, rank := range cards.Ranks {
+		held := 0
+		for _, suit := range cards.Suits {
+			card := cards.Card{Suit: suit, Rank: rank}
+			if dealt[card] > numDecks {
+				return fmt.Errorf("%s was dealt %d times from %d decks", card, dealt[card], numDecks)
+			}
+			held += dealt[card]
+		}
+		if held+remaining[rank] > numDecks*len(cards.Suits) {
+			return fmt.Errorf("%d %ss are held or in the shoe of %d decks", held+remaining[rank], rank, numDecks)
+		}
+	}
+
+	return checkDealer(game, dealer, phase)
+}
+
+// checkHand verifies that the hand holds no more cards than its bets and actions
+// allow in the phase
+func checkHand(player *blackjack.Player, hand *blackjack.Hand, phase blackjack.Phase, rules blackjack.Rules) error {
+	name := fmt.Sprintf("%s's %s", player.Name(), hand.Label())
+	count := hand.Count()
+	switch phase {
+	case blackjack.PhaseWaiting, blackjack.PhaseBetting:
+		if count > 0 {
+			return fmt.Errorf("%s holds %d cards during the %s phase", name, count, phase)
+		}
+	case blackjack.PhaseComplete:
+		if hand.Bet() > 0 && count > 0 && !hand.IsSettled() {
+			return fmt.Errorf("%s wasn't settled", name)
+		}
+	}
+	if hand.IsSurrendered() && count != 2 {
+		return fmt.Errorf("%s was surrendered holding %d cards", name, count)
+	}
+	if hand.IsSplit() && count > 2 && hand.Cards()[0].Rank == cards.Ace && !rules.HitSplitAces {
+		return fmt.Errorf("%s holds %d cards from split aces", name, count)
+	}
+	if count > 2 {
+		// The hand must have been free to take another card before its last one
+		total, _ := value(hand.Cards()[:count-1])
+		if total >= 21 {
+			return fmt.Errorf("%s was dealt a card on %d", name, total)
+		}
+		if rules.CharlieCards > 0 && count > rules.CharlieCards {
+			return fmt.Errorf("%s holds %d cards, more than a %d-card Charlie", name, count, rules.CharlieCards)
+		}
+	}
+	return nil
+}
+
+// checkDealer verifies that the dealer holds the cards the phase calls for, and
+// only drew cards the dealer's rules called for
+func checkDealer(game *blackjack.Game, dealer []cards.Card, phase blackjack.Phase) error {
+	revealed := game.Dealer().IsHoleCardRevealed()
+	switch phase {
+	case blackjack.PhaseWaiting, blackjack.PhaseBetting:
+		if len(dealer) > 0 {
+			return fmt.Errorf("the dealer holds %d cards during the %s phase", len(dealer), phase)
+		}
+	case blackjack.PhasePlayerTurns:
+		if len(dealer) != 2 || revealed {
+			return fmt.Errorf("the dealer holds %d cards (revealed %t) during the player turns", len(dealer), revealed)
+		}
+	}
+	if len(dealer) > 2 {
+		total, soft := value(dealer[:len(dealer)-1])
+		if total > 17 || total == 17 && (!soft || game.Rules().StandSoft17) {
+			return fmt.Errorf("the dealer drew a card on %d", total)
+		}
+	}
+	return nil
+}
+
+// value returns the blackjack total of the cards and whether it is soft
+func value(hand []cards.Card) (int, bool) {
+	total, ace := 0, false
+	for _, card := range hand {
+		total += odds.Value(card)
+		ace = ace || card.Rank == cards.Ace
+	}
+	if ace && total+10 <= 21 {
+		return total + 10, true
+	}
+	return total, false
+}
@@ -0,0 +1,24 @@
+package fuzz
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func FuzzGame(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte("blackjack"))
+	for seed := range uint64(8) {
+		rng := rand.New(rand.NewPCG(seed, seed))
+		data := make([]byte, 16+200*bytesPerStep)
+		for i := range data {
+			data[i] = byte(rng.Uint32())
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := Run(data); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
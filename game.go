@@ -1,9 +1,18 @@
 package blackjack
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math/big"
 	"strings"
+	"time"
+
+	"github.com/rbrabson/cards"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // GameResult represents the outcome of a hand
@@ -16,48 +25,222 @@ const (
 	Push                       // Push represents a tie
 	PlayerBlackjack            // PlayerBlackjack represents a player blackjack
 	DealerBlackjack            // DealerBlackjack represents a dealer blackjack
+	Surrendered                // Surrendered represents a hand the player surrendered
+	PlayerCharlie              // PlayerCharlie represents a win for the player on a Charlie
 )
 
 // String returns a string representation of the game result
 func (gr GameResult) String() string {
 	switch gr {
 	case PlayerWin:
-		return "Player Wins"
+		return Message(MsgResultPlayerWin)
 	case DealerWin:
-		return "Dealer Wins"
+		return Message(MsgResultDealerWin)
 	case Push:
-		return "Push (Tie)"
+		return Message(MsgResultPush)
 	case PlayerBlackjack:
-		return "Player Blackjack!"
+		return Message(MsgResultPlayerBlackjack)
 	case DealerBlackjack:
-		return "Dealer Blackjack!"
+		return Message(MsgResultDealerBlackjack)
+	case Surrendered:
+		return Message(MsgResultSurrendered)
+	case PlayerCharlie:
+		return Message(MsgResultPlayerCharlie)
 	default:
-		return "Unknown"
+		return Message(MsgUnknown)
+	}
+}
+
+// resultNames are the stable names game results are serialized as, indexed by result
+var resultNames = [...]string{
+	PlayerWin:       "player_win",
+	DealerWin:       "dealer_win",
+	Push:            "push",
+	PlayerBlackjack: "player_blackjack",
+	DealerBlackjack: "dealer_blackjack",
+	Surrendered:     "surrendered",
+	PlayerCharlie:   "player_charlie",
+}
+
+// ParseGameResult returns the game result with the stable name, such as
+// "player_blackjack"
+func ParseGameResult(name string) (GameResult, error) {
+	for result, n := range resultNames {
+		if n != "" && n == name {
+			return GameResult(result), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown game result %q", name)
+}
+
+// MarshalText returns the stable name of the result, such as "player_blackjack",
+// so that serialized histories don't depend on the order of the constants. A result
+// that hasn't been set is empty.
+func (gr GameResult) MarshalText() ([]byte, error) {
+	if gr == 0 {
+		return []byte{}, nil
+	}
+	if gr < 0 || int(gr) >= len(resultNames) {
+		return nil, fmt.Errorf("unknown game result %d", int(gr))
+	}
+	return []byte(resultNames[gr]), nil
+}
+
+// UnmarshalText sets the result from its stable name
+func (gr *GameResult) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*gr = 0
+		return nil
+	}
+	result, err := ParseGameResult(string(text))
+	if err != nil {
+		return err
+	}
+	*gr = result
+	return nil
+}
+
+// UnmarshalJSON sets the result from its stable name, or from the number results
+// were serialized as before they had names
+func (gr *GameResult) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		if n < 0 || n >= len(resultNames) {
+			return fmt.Errorf("unknown game result %d", n)
+		}
+		*gr = GameResult(n)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("game result must be a name or a number: %w", err)
 	}
+	return gr.UnmarshalText([]byte(name))
 }
 
 // Game represents the main game
 type Game struct {
-	dealer  *Dealer   // dealer is the game dealer
-	players []*Player // players are the game players
-	shoe    *Shoe     // shoe are the cards used in the game
-	round   int       // round is the current round number
+	id             string         // id uniquely identifies the game
+	dealer         *Dealer        // dealer is the game dealer
+	players        []*Player      // players are the game players
+	shoe           *Shoe          // shoe are the cards used in the game
+	rules          Rules          // rules are the table rules
+	phase          Phase          // phase is the stage of the current round
+	round          int            // round is the current round number
+	roundID        int64          // roundID identifies the current round and never decreases, even across resets
+	roundStarted   time.Time      // roundStarted is when the current round was started
+	lastDecision   time.Time      // lastDecision is when the table last waited on a player's decision
+	payout         PayoutPolicy   // payout determines the amount paid on each settled hand
+	record         *RoundRecord   // record captures the round in progress
+	history        []*RoundRecord // history holds the most recently completed rounds
+	listeners      []Listener     // listeners receive the game's events
+	actionInterval time.Duration  // actionInterval is the minimum time between a player's actions
+	snapshots      GameStore      // snapshots receives a snapshot of the game after each phase transition
+	tracer         trace.Tracer   // tracer traces the game's operations, if tracing is enabled
+	roundSpan      trace.Span     // roundSpan is the span of the round in progress
+	logger         *slog.Logger   // logger receives the game's log messages
+	currency       Currency       // currency is the unit chip amounts are counted in
+	bonus          Bonus          // bonus is the free chips players may claim
+	cutter         Cutter         // cutter cuts the shoe after each reshuffle, if any
+	burn           int            // burn is the number of cards burned after each reshuffle
+	shufflePolicy  ShufflePolicy  // shufflePolicy decides when the shoe is reshuffled
+
+	idempotencyResults map[idempotencyKey]idempotentResult // idempotencyResults are the remembered results of keyed requests
+	idempotencyKeys    []idempotencyKey                    // idempotencyKeys are the remembered keys, oldest first
 }
 
+// GameOption is a function that configures a game.
+type GameOption func(*Game)
+
 // New creates a new blackjack game
-func New(numDecks int) *Game {
-	return &Game{
+func New(numDecks int, options ...GameOption) *Game {
+	game := &Game{
+		id:      newUUID(),
 		dealer:  NewDealer(),
 		players: make([]*Player, 0, 1),
 		shoe:    NewShoe(numDecks),
+		rules:   DefaultRules(),
+		phase:   PhaseWaiting,
 		round:   0,
+		payout:  DefaultPayoutPolicy{},
+	}
+	game.dealer.game = game
+	for _, option := range options {
+		option(game)
+	}
+	if game.shoe.rng != nil {
+		// Deal the first shoe from the seed, too
+		game.shoe.Reshuffle()
+	}
+	return game
+}
+
+// WithPayoutPolicy sets the policy used to compute the payout of each settled hand.
+func WithPayoutPolicy(policy PayoutPolicy) GameOption {
+	return func(g *Game) {
+		g.payout = policy
+	}
+}
+
+// ID returns the unique identifier of the game
+func (bg *Game) ID() string {
+	return bg.id
+}
+
+// Reset clears the round count, round history, and all hands so the table can be
+// reused. Players and their current chip counts are kept. If the bets of a round
+// in progress can't be returned, the game is left as it was and the chip
+// manager's error is returned.
+func (bg *Game) Reset() error {
+	if err := bg.refundBets(); err != nil {
+		return err
 	}
+	bg.round = 0
+	bg.phase = PhaseWaiting
+	bg.record = nil
+	bg.history = nil
+	bg.endRoundSpan()
+	bg.dealer.ClearHand()
+	for _, player := range bg.players {
+		player.ClearHands()
+		player.SetActive(true)
+		player.waiting = false
+	}
+	bg.snapshot()
+	return nil
+}
+
+// NewSession resets the game and restores every player's chips to the amount
+// they started with. It returns any error from resetting the game.
+func (bg *Game) NewSession() error {
+	if err := bg.Reset(); err != nil {
+		return err
+	}
+	for _, player := range bg.players {
+		player.ResetChips()
+	}
+	return nil
 }
 
-// AddPlayer adds a player to the game
+// AddPlayer adds a player to the game. A player added while a round is in progress
+// sits out the rest of that round and is dealt in when the next round starts.
 func (bg *Game) AddPlayer(name string, options ...Option) {
 	player := NewPlayer(name, options...)
+	player.game = bg
+	details := "joined the table"
+	if bg.roundInProgress() {
+		player.waiting = true
+		player.SetActive(false)
+		details = "joined the table and will be dealt in next round"
+	}
 	bg.players = append(bg.players, player)
+	bg.log().Info("player joined", "player", name, "chips", player.Chips(), "waiting", player.waiting)
+	bg.emit(Event{Type: EventPlayerJoined, Player: name, Details: details})
+}
+
+// roundInProgress returns true if a round has been started but not yet settled
+func (bg *Game) roundInProgress() bool {
+	return bg.phase != PhaseWaiting && bg.phase != PhaseComplete
 }
 
 // GetPlayer returns a player by name
@@ -70,15 +253,60 @@ func (bg *Game) GetPlayer(name string) *Player {
 	return nil
 }
 
-// RemovePlayer removes a player from the game
-func (bg *Game) RemovePlayer(name string) bool {
+// RemovePlayer removes a player from the game. A player who leaves a round before
+// the cards are dealt has their bets returned, and a player who leaves once the
+// cards are dealt forfeits the bets on their unsettled hands. If the chip manager
+// fails to return or forfeit the bets, the player stays seated and the error is
+// returned.
+func (bg *Game) RemovePlayer(name string) error {
 	for i, player := range bg.players {
 		if player.Name() == name {
+			if err := bg.leaveRound(player); err != nil {
+				return err
+			}
+			player.game = nil
 			bg.players = append(bg.players[:i], bg.players[i+1:]...)
-			return true
+			bg.log().Info("player left", "player", name, "chips", player.Chips())
+			return nil
 		}
 	}
-	return false
+	return fmt.Errorf("player %s not found", name)
+}
+
+// RenamePlayer changes the name of a player. The player's chips, statistics, and
+// hands are kept, and the rounds they played in the game's history are attributed
+// to the new name.
+func (bg *Game) RenamePlayer(name string, newName string) error {
+	player := bg.GetPlayer(name)
+	if player == nil {
+		return fmt.Errorf("player %s not found", name)
+	}
+	if newName == "" {
+		return fmt.Errorf("player name may not be empty")
+	}
+	if newName == name {
+		return nil
+	}
+	if bg.GetPlayer(newName) != nil {
+		return fmt.Errorf("player %s already exists", newName)
+	}
+
+	if member, ok := player.chipManager.(*BankrollMember); ok {
+		if err := member.bankroll.rename(member, newName); err != nil {
+			return err
+		}
+	}
+	player.name = newName
+	for _, record := range append(bg.history, bg.record) {
+		if record != nil {
+			record.renamePlayer(name, newName)
+		}
+	}
+
+	bg.log().Info("player renamed", "player", newName, "previous", name)
+	bg.emit(Event{Type: EventPlayerRenamed, Player: newName, Details: fmt.Sprintf("renamed from %s", name)})
+	bg.snapshot()
+	return nil
 }
 
 // Players returns a copy of the players slice
@@ -93,16 +321,73 @@ func (bg *Game) Dealer() *Dealer {
 	return bg.dealer
 }
 
+// Rules returns the table rules
+func (bg *Game) Rules() Rules {
+	return bg.rules
+}
+
+// Phase returns the stage of the current round
+func (bg *Game) Phase() Phase {
+	return bg.phase
+}
+
+// PayoutPolicy returns the policy used to pay settled hands
+func (bg *Game) PayoutPolicy() PayoutPolicy {
+	return bg.payout
+}
+
+// SetPayoutPolicy sets the policy used to pay settled hands
+func (bg *Game) SetPayoutPolicy(policy PayoutPolicy) {
+	bg.payout = policy
+}
+
 // Shoe returns the shoe
 func (bg *Game) Shoe() *Shoe {
 	return bg.shoe
 }
 
+// SetShoe replaces the shoe used by the game, such as with a stacked shoe created
+// by NewShoeFromCards to reproduce a recorded round
+func (bg *Game) SetShoe(shoe *Shoe) {
+	bg.shoe = shoe
+}
+
 // Round returns the current round number
 func (bg *Game) Round() int {
 	return bg.round
 }
 
+// RoundID returns the identifier of the current round. Round IDs increase with
+// every round played and, unlike the round number, are not reset by Reset.
+func (bg *Game) RoundID() int64 {
+	return bg.roundID
+}
+
+// RoundStartedAt returns the time the current round was started
+func (bg *Game) RoundStartedAt() time.Time {
+	return bg.roundStarted
+}
+
+// drawCard draws the next card from the shoe and records it for the current round
+func (bg *Game) drawCard() (cards.Card, error) {
+	if bg.shoe.IsEmpty() {
+		bg.reshuffle("the shoe ran out of cards")
+	}
+	reached := bg.shoe.NeedsReshuffle()
+	card, err := bg.shoe.Draw()
+	if err != nil {
+		return card, err
+	}
+	if bg.record != nil {
+		bg.record.Cards = append(bg.record.Cards, card)
+	}
+	if !reached && bg.shoe.NeedsReshuffle() && bg.shufflePolicy == ShuffleAtCutCard {
+		bg.log().Debug("cut card reached", "remaining", bg.shoe.CardsRemaining())
+		bg.emit(Event{Type: EventCutCardReached, Details: "shuffle after this round"})
+	}
+	return card, nil
+}
+
 // DealCard deals a card from the shoe
 func (bg *Game) DealCard() error {
 	if bg.shoe.IsEmpty() {
@@ -110,8 +395,7 @@ func (bg *Game) DealCard() error {
 	}
 
 	if bg.shoe.NeedsReshuffle() {
-		slog.Debug("Reshuffling blackjack shoe...")
-		bg.shoe.Reshuffle()
+		bg.reshuffle("the cut card was reached")
 	}
 
 	return nil
@@ -119,203 +403,484 @@ func (bg *Game) DealCard() error {
 
 // StartNewRound starts a new round of blackjack
 func (bg *Game) StartNewRound() error {
+	if err := bg.refundBets(); err != nil {
+		return err
+	}
 	bg.round++
+	bg.roundID++
+	bg.roundStarted = time.Now()
+	bg.record = newRoundRecord(bg.roundID, bg.round, bg.roundStarted, bg.rules)
+	bg.phase = PhaseBetting
+	bg.startRoundSpan()
 
-	// Clear all hands
+	// Clear all hands, dealing in any players who joined during the last round
 	bg.dealer.ClearHand()
 	for _, player := range bg.players {
 		player.ClearHands()
 		player.SetActive(true)
+		player.waiting = false
 	}
 
 	// Check if we need to reshuffle
-	if bg.shoe.NeedsReshuffle() {
-		slog.Debug("Reshuffling blackjack shoe...")
-		bg.shoe.Reshuffle()
+	if due, reason := bg.shuffleDue(); due {
+		bg.reshuffle(reason)
 	}
 
+	bg.log().Info("round started", "number", bg.round, "players", len(bg.players))
+	bg.emit(Event{Type: EventRoundStarted, Details: fmt.Sprintf("round %d", bg.round)})
+	bg.snapshot()
 	return nil
 }
 
-// DealInitialCards deals two cards to each player and dealer
+// SetPlayerSpots sets the number of spots (initial hands) a player plays each round.
+// Each spot is dealt its own hand and takes its own bet. Spots may only be changed
+// between rounds or while bets are being placed.
+func (bg *Game) SetPlayerSpots(playerName string, spots int) error {
+	player := bg.GetPlayer(playerName)
+	if player == nil {
+		return fmt.Errorf("player %s not found", playerName)
+	}
+
+	if bg.phase != PhaseWaiting && bg.phase != PhaseBetting && bg.phase != PhaseComplete {
+		return fmt.Errorf("spots may not be changed during the %s phase", strings.ToLower(bg.phase.String()))
+	}
+
+	if spots < 1 || spots > max(1, bg.rules.MaxSpots) {
+		return fmt.Errorf("player %s may play between 1 and %d spots", playerName, max(1, bg.rules.MaxSpots))
+	}
+
+	return player.setSpots(spots)
+}
+
+// validateBet returns an error if a bet of the given amount may not be placed
+func (bg *Game) validateBet(amount int) error {
+	if bg.phase != PhaseBetting {
+		return fmt.Errorf("bets may not be placed during the %s phase", strings.ToLower(bg.phase.String()))
+	}
+	return bg.rules.ValidateBet(amount)
+}
+
+// DealInitialCards deals two cards to each player and dealer. If the dealer peeks
+// and has blackjack, the round is settled immediately and no player turns are taken.
 func (bg *Game) DealInitialCards() error {
+	_, span := bg.startSpan(context.Background(), "blackjack.deal")
+	err := bg.dealInitialCards()
+	endSpan(span, err)
+	return err
+}
+
+// dealInitialCards deals the initial cards
+func (bg *Game) dealInitialCards() error {
+	if bg.phase != PhaseBetting {
+		return fmt.Errorf("initial cards may not be dealt during the %s phase", strings.ToLower(bg.phase.String()))
+	}
+
+	// Spots without a bet are not dealt in when a player plays more than one spot
+	for _, player := range bg.players {
+		player.removeUnbetSpots()
+	}
+
+	if err := bg.dealCards(); err != nil {
+		// Return the bets rather than leave them riding on a round that can't be played
+		return bg.abortAfter(err)
+	}
+
+	if bg.record != nil {
+		bg.record.recordSeats(bg.players)
+	}
+	// A player's first hand may already be finished with a blackjack
+	for _, player := range bg.players {
+		if player.IsActive() {
+			player.MoveToNextActiveHand()
+		}
+	}
+	bg.phase = PhasePlayerTurns
+	bg.lastDecision = time.Now()
+	bg.snapshot()
+
+	if bg.dealerPeeksBlackjack() {
+		bg.log().Debug("dealer has blackjack, settling round")
+		bg.dealer.RevealHoleCard()
+		for _, player := range bg.players {
+			player.SetActive(false)
+		}
+		return bg.PayoutResults()
+	}
+
+	return nil
+}
+
+// dealCards deals two cards to each player and the dealer
+func (bg *Game) dealCards() error {
 	// Deal first card to each player
 	for _, player := range bg.players {
 		if player.IsActive() {
-			for _, hand := range player.hands {
-				card, err := bg.shoe.Draw()
+			for i, hand := range player.hands {
+				card, err := bg.drawCard()
 				if err != nil {
 					return fmt.Errorf("failed to deal card to %s: %w", player.Name(), err)
 				}
 				hand.DealCard(card)
+				bg.cardDealt(player, i, &card, "initial deal")
 			}
 		}
 	}
 
 	// Deal first card to dealer
-	card, err := bg.shoe.Draw()
+	card, err := bg.drawCard()
 	if err != nil {
 		return fmt.Errorf("failed to deal card to dealer: %w", err)
 	}
 	bg.dealer.DealCard(card)
+	bg.cardDealt(nil, 0, &card, "upcard")
 
 	// Deal second card to each player
 	for _, player := range bg.players {
 		if player.IsActive() {
-			for _, hand := range player.hands {
-				card, err := bg.shoe.Draw()
+			for i, hand := range player.hands {
+				card, err := bg.drawCard()
 				if err != nil {
 					return fmt.Errorf("failed to deal card to %s: %w", player.Name(), err)
 				}
 				hand.DealCard(card)
+				bg.cardDealt(player, i, &card, "initial deal")
 			}
 		}
 	}
 
 	// Deal second card to dealer (hole card)
-	card, err = bg.shoe.Draw()
+	card, err = bg.drawCard()
 	if err != nil {
 		return fmt.Errorf("failed to deal hole card to dealer: %w", err)
 	}
 	bg.dealer.DealCard(card)
+	bg.cardDealt(nil, 0, nil, "hole card")
 
 	return nil
 }
 
-// PlayerHit deals a card to a specific player
-func (bg *Game) PlayerHit(playerName string) error {
+// dealerPeeksBlackjack returns true if the dealer's upcard allows a peek at the
+// hole card and the dealer has blackjack
+func (bg *Game) dealerPeeksBlackjack() bool {
+	if !bg.rules.DealerPeek {
+		return false
+	}
+	upCard, ok := bg.dealer.UpCard()
+	_, hasHoleCard := bg.dealer.HoleCard()
+	if !ok || !hasHoleCard {
+		return false
+	}
+	switch upCard.Rank {
+	case cards.Ace, cards.Ten, cards.Jack, cards.Queen, cards.King:
+		return bg.dealer.HasBlackjack()
+	default:
+		return false
+	}
+}
+
+// actingPlayer returns the named player if they may act on their hand right now
+func (bg *Game) actingPlayer(playerName string) (*Player, error) {
 	player := bg.GetPlayer(playerName)
 	if player == nil {
-		return fmt.Errorf("player %s not found", playerName)
+		return nil, fmt.Errorf("player %s not found", playerName)
+	}
+
+	if bg.phase != PhasePlayerTurns {
+		return nil, fmt.Errorf("players may not act during the %s phase", strings.ToLower(bg.phase.String()))
 	}
 
 	if !player.IsActive() {
-		return fmt.Errorf("player %s is not active", playerName)
+		return nil, fmt.Errorf("player %s is not active", playerName)
+	}
+
+	if err := bg.checkRateLimit(player); err != nil {
+		return nil, err
+	}
+
+	return player, nil
+}
+
+// PlayerHit deals a card to a specific player. If the hand busts it is marked
+// inactive and the player moves on to their next active hand.
+func (bg *Game) PlayerHit(playerName string) error {
+	_, err := bg.playerHit(playerName)
+	return err
+}
+
+// playerHit deals a card to the player's current hand and reports the outcome
+func (bg *Game) playerHit(playerName string) (ActionOutcome, error) {
+	player, err := bg.actingPlayer(playerName)
+	if err != nil {
+		return ActionOutcome{}, err
 	}
 
 	if player.IsStanding() {
-		return fmt.Errorf("player %s is already standing", playerName)
+		return ActionOutcome{}, fmt.Errorf("player %s is already standing", playerName)
+	}
+	hand := player.CurrentHand()
+	if !hand.CanHit() {
+		return ActionOutcome{}, fmt.Errorf("player %s cannot hit this hand", playerName)
 	}
 
-	card, err := bg.shoe.Draw()
+	card, err := bg.drawCard()
 	if err != nil {
-		return fmt.Errorf("failed to deal card: %w", err)
+		return ActionOutcome{}, fmt.Errorf("failed to deal card: %w", err)
 	}
 
-	player.CurrentHand().Hit(card)
-	return nil
+	outcome := ActionOutcome{HandIndex: player.GetCurrentHandNumber(), Cards: []cards.Card{card}}
+	hand.Hit(card)
+	bg.cardDealt(player, outcome.HandIndex, &card, "hit")
+	if hand.IsBusted() {
+		hand.SetActive(false)
+		hand.RecordAction(ActionBust, fmt.Sprintf("busted with %d", hand.Value()))
+		bg.emit(Event{Type: EventHandBusted, Player: playerName, Hand: outcome.HandIndex, Card: &card})
+	}
+
+	bg.finishAction(player, &outcome)
+	return outcome, nil
 }
 
-// PlayerDoubleDownHit deals a card to a specific player as part of a double down
+// PlayerDoubleDownHit deals the one additional card to the player's current hand
+// after it was doubled down with Hand.DoubleDown, moving the player on to their next
+// active hand. The hand must have been doubled down and not yet dealt its card.
 func (bg *Game) PlayerDoubleDownHit(playerName string) error {
-	player := bg.GetPlayer(playerName)
-	if player == nil {
-		return fmt.Errorf("player %s not found", playerName)
+	player, err := bg.actingPlayer(playerName)
+	if err != nil {
+		return err
 	}
 
-	if !player.IsActive() {
-		return fmt.Errorf("player %s is not active", playerName)
+	hand := player.CurrentHand()
+	if !hand.awaitingDoubleDownCard() {
+		return fmt.Errorf("player %s has no double down card to be dealt", playerName)
 	}
 
-	card, err := bg.shoe.Draw()
+	card, err := bg.drawCard()
 	if err != nil {
 		return fmt.Errorf("failed to deal card: %w", err)
 	}
 
-	player.CurrentHand().DoubleDownHit(card)
+	outcome := ActionOutcome{HandIndex: player.GetCurrentHandNumber(), Cards: []cards.Card{card}}
+	hand.DoubleDownHit(card)
+	bg.cardDealt(player, outcome.HandIndex, &card, "double down")
+	bg.finishAction(player, &outcome)
 	return nil
 }
 
-// PlayerSplit processes a split action for the specified player.
-func (bg *Game) PlayerSplit(playerName string) error {
-	player := bg.GetPlayer(playerName)
-	if player == nil {
-		return fmt.Errorf("player %s not found", playerName)
+// PlayerDoubleDown doubles the bet on the player's current hand, deals it exactly one
+// card, and stands the hand, moving the player on to their next active hand
+func (bg *Game) PlayerDoubleDown(playerName string) error {
+	_, err := bg.playerDoubleDown(context.Background(), playerName)
+	return err
+}
+
+// playerDoubleDown doubles down on the player's current hand and reports the outcome,
+// passing ctx to the player's chip manager
+func (bg *Game) playerDoubleDown(ctx context.Context, playerName string) (ActionOutcome, error) {
+	player, err := bg.actingPlayer(playerName)
+	if err != nil {
+		return ActionOutcome{}, err
 	}
-	if err := player.CurrentHand().Split(); err != nil {
-		return err
+
+	if player.IsStanding() {
+		return ActionOutcome{}, fmt.Errorf("player %s is already standing", playerName)
+	}
+
+	hand := player.CurrentHand()
+	if !hand.canDoubleDown(ctx) {
+		return ActionOutcome{}, fmt.Errorf("player %s cannot double down at this time", playerName)
+	}
+
+	card, err := bg.drawCard()
+	if err != nil {
+		return ActionOutcome{}, fmt.Errorf("failed to deal card: %w", err)
+	}
+
+	if err := hand.doubleDownWith(ctx, card); err != nil {
+		return ActionOutcome{}, err
+	}
+
+	outcome := ActionOutcome{HandIndex: player.GetCurrentHandNumber(), Cards: []cards.Card{card}}
+	bg.cardDealt(player, outcome.HandIndex, &card, "double down")
+	bg.finishAction(player, &outcome)
+	return outcome, nil
+}
+
+// ActionOutcome describes the effect of a player's action so that callers do not
+// need to re-query the game after every action
+type ActionOutcome struct {
+	HandIndex int          `json:"hand_index"`          // HandIndex is the index of the hand the action was taken on
+	Cards     []cards.Card `json:"cards"`               // Cards are the cards dealt as a result of the action
+	NewHands  []int        `json:"new_hands,omitempty"` // NewHands are the indexes of any hands created by the action
+	NextHand  int          `json:"next_hand"`           // NextHand is the index of the player's current hand after the action
+	TurnEnded bool         `json:"turn_ended"`          // TurnEnded is true if the player has no more hands to play
+}
+
+// finishAction moves the player to their next active hand if the current hand is
+// done, and completes the outcome with the player's position after the action
+func (bg *Game) finishAction(player *Player, outcome *ActionOutcome) {
+	bg.recordRateLimit(player)
+	bg.recordDecision(player)
+	if player.IsStanding() && !player.MoveToNextActiveHand() {
+		// No more active hands, player is done
+		player.SetActive(false)
+	}
+	outcome.NextHand = player.GetCurrentHandNumber()
+	outcome.TurnEnded = !player.IsActive()
+	if hand := player.hands[outcome.HandIndex]; len(hand.actions) > 0 {
+		bg.log().Debug("player acted", "player", player.Name(), "hand", outcome.HandIndex,
+			"action", hand.actions[len(hand.actions)-1].Type, "value", hand.Value(), "turn_ended", outcome.TurnEnded)
+	}
+	if outcome.TurnEnded {
+		bg.emit(Event{Type: EventTurnEnded, Player: player.Name(), Hand: outcome.HandIndex})
+	}
+}
+
+// PlayerSplit processes a split action for the specified player.
+func (bg *Game) PlayerSplit(playerName string) (ActionOutcome, error) {
+	return bg.playerSplit(context.Background(), playerName)
+}
+
+// playerSplit splits the player's current hand, passing ctx to the player's chip manager
+func (bg *Game) playerSplit(ctx context.Context, playerName string) (ActionOutcome, error) {
+	player, err := bg.actingPlayer(playerName)
+	if err != nil {
+		return ActionOutcome{}, err
 	}
 
-	// Deal one card to each of the new split hands
+	outcome := ActionOutcome{HandIndex: player.GetCurrentHandNumber()}
+	hand := player.CurrentHand()
+	if err := hand.split(ctx); err != nil {
+		return outcome, err
+	}
 	hands := player.Hands()
-	for _, splitHand := range hands[len(hands)-2:] {
-		card, err := bg.Shoe().Draw()
+	newHandIdx := len(hands) - 1
+	outcome.NewHands = []int{newHandIdx}
+
+	// Deal one card to each of the split hands
+	for _, handIdx := range []int{outcome.HandIndex, newHandIdx} {
+		card, err := bg.drawCard()
 		if err != nil {
-			return fmt.Errorf("failed to deal card to split hand for player %s: %w", playerName, err)
+			return outcome, fmt.Errorf("failed to deal card to split hand for player %s: %w", playerName, err)
 		}
-		splitHand.Hit(card)
+		hands[handIdx].dealSplitCard(card)
+		outcome.Cards = append(outcome.Cards, card)
+		bg.cardDealt(player, handIdx, &card, "split")
 	}
 
-	return nil
+	bg.finishAction(player, &outcome)
+	return outcome, nil
 }
 
 // PlayerStand handles a player standing on their current hand
-func (bg *Game) PlayerStand(playerName string) error {
-	player := bg.GetPlayer(playerName)
-	if player == nil {
-		return fmt.Errorf("player %s not found", playerName)
-	}
-
-	if !player.IsActive() {
-		return fmt.Errorf("player %s is not active", playerName)
+func (bg *Game) PlayerStand(playerName string) (ActionOutcome, error) {
+	player, err := bg.actingPlayer(playerName)
+	if err != nil {
+		return ActionOutcome{}, err
 	}
 
 	// Stand on current hand
+	outcome := ActionOutcome{HandIndex: player.GetCurrentHandNumber()}
 	player.CurrentHand().Stand()
 
-	// Move to next active hand if available
-	if !player.MoveToNextActiveHand() {
-		// No more active hands, player is done
-		player.SetActive(false)
-	}
-
-	return nil
+	bg.finishAction(player, &outcome)
+	return outcome, nil
 }
 
 // PlayerSurrender handles a player surrendering their current hand
-func (bg *Game) PlayerSurrender(playerName string) error {
-	player := bg.GetPlayer(playerName)
-	if player == nil {
-		return fmt.Errorf("player %s not found", playerName)
-	}
+func (bg *Game) PlayerSurrender(playerName string) (ActionOutcome, error) {
+	return bg.playerSurrender(context.Background(), playerName)
+}
 
-	if !player.IsActive() {
-		return fmt.Errorf("player %s is not active", playerName)
+// playerSurrender surrenders the player's current hand, passing ctx to the player's
+// chip manager
+func (bg *Game) playerSurrender(ctx context.Context, playerName string) (ActionOutcome, error) {
+	player, err := bg.actingPlayer(playerName)
+	if err != nil {
+		return ActionOutcome{}, err
 	}
 
+	outcome := ActionOutcome{HandIndex: player.GetCurrentHandNumber()}
 	hand := player.CurrentHand()
 	if !hand.CanSurrender() {
-		return fmt.Errorf("player %s cannot surrender at this time", playerName)
+		return outcome, fmt.Errorf("player %s cannot surrender at this time", playerName)
 	}
 
-	// Surrender the current hand
-	hand.Surrender()
-
-	// Move to next active hand if available
-	if !player.MoveToNextActiveHand() {
-		// No more active hands, player is done
-		player.SetActive(false)
+	// Surrender the current hand. The hand may have been surrendered even if the
+	// chip manager then failed to commit the chips lost.
+	err = hand.surrender(ctx)
+	if hand.IsSurrendered() {
+		bg.finishAction(player, &outcome)
 	}
-
-	return nil
+	return outcome, err
 }
 
-// DealerPlay handles the dealer's turn according to blackjack rules
+// DealerPlay handles the dealer's turn according to blackjack rules, once every
+// player has finished their hands
 func (bg *Game) DealerPlay() error {
+	_, span := bg.startSpan(context.Background(), "blackjack.dealer_play")
+	err := bg.dealerPlay()
+	span.SetAttributes(attribute.Int("blackjack.dealer.value", bg.dealer.Hand().Value()))
+	endSpan(span, err)
+	return err
+}
+
+// dealerPlay plays the dealer's hand
+func (bg *Game) dealerPlay() error {
+	if bg.phase != PhasePlayerTurns && bg.phase != PhaseDealerTurn {
+		return fmt.Errorf("the dealer may not play during the %s phase", strings.ToLower(bg.phase.String()))
+	}
+	if active := bg.GetActivePlayer(); bg.phase == PhasePlayerTurns && active != nil {
+		return fmt.Errorf("the dealer may not play while %s has hands to play", active.Name())
+	}
+
+	bg.phase = PhaseDealerTurn
+	bg.dealer.RevealHoleCard()
 	for bg.dealer.ShouldHit() {
-		card, err := bg.shoe.Draw()
+		card, err := bg.drawCard()
 		if err != nil {
 			return fmt.Errorf("failed to deal card to dealer: %w", err)
 		}
 		bg.dealer.Hit(card)
+		bg.cardDealt(nil, 0, &card, "dealer hit")
 	}
 	// Record that dealer is standing
 	bg.dealer.Stand()
+	bg.log().Debug("dealer played", "value", bg.dealer.Hand().Value(), "busted", bg.dealer.Hand().IsBusted())
+	bg.snapshot()
 	return nil
 }
 
-// EvaluateHand determines the result of a player's hand against the dealer
-func (bg *Game) EvaluateHand(playerHand *Hand) GameResult {
+// SettleIfFinished plays the dealer's hand and settles the round once every player
+// has finished their hands. It returns true if the round was settled. A settlement
+// that failed because the chip manager couldn't pay a hand is retried.
+func (bg *Game) SettleIfFinished() (bool, error) {
+	switch {
+	case bg.phase == PhasePlayerTurns && bg.GetActivePlayer() == nil:
+	case bg.phase == PhaseDealerTurn:
+	default:
+		return false, nil
+	}
+	if !bg.dealer.hand.isStood {
+		if err := bg.DealerPlay(); err != nil {
+			return false, err
+		}
+	}
+	if err := bg.PayoutResults(); err != nil {
+		return bg.phase == PhaseComplete, err
+	}
+	return true, nil
+}
+
+// Evaluation is the detailed outcome of a player's hand against the dealer
+type Evaluation struct {
+	Result      GameResult // Result is the outcome of the hand
+	PlayerValue int        // PlayerValue is the value of the player's hand
+	DealerValue int        // DealerValue is the value of the dealer's hand
+	Multiplier  *big.Rat   // Multiplier is the net payout as an exact fraction of the bet (e.g., 3/2 for blackjack, -1 for a loss)
+}
+
+// Evaluate determines the result of a player's hand against the dealer
+func (bg *Game) Evaluate(playerHand *Hand) Evaluation {
 	dealerHand := bg.dealer.Hand()
 
 	playerBlackjack := playerHand.IsBlackjack()
@@ -323,51 +888,179 @@ func (bg *Game) EvaluateHand(playerHand *Hand) GameResult {
 	playerValue := playerHand.Value()
 	dealerValue := dealerHand.Value()
 
+	eval := Evaluation{
+		PlayerValue: playerValue,
+		DealerValue: dealerValue,
+	}
+
 	switch {
+	case playerHand.IsSurrendered():
+		// A surrendered hand loses half its bet, even to a dealer blackjack
+		eval.Result = Surrendered
 	case playerBlackjack && dealerBlackjack:
-		return Push
+		eval.Result = Push
 	case playerBlackjack:
-		return PlayerBlackjack
+		eval.Result = PlayerBlackjack
 	case dealerBlackjack:
-		return DealerBlackjack
-	case playerHand.IsSurrendered():
-		return DealerWin
-	case playerHand.IsBusted():
-		return DealerWin
-	case dealerHand.IsBusted():
-		return PlayerWin
-	case playerValue > dealerValue:
-		return PlayerWin
-	case dealerValue > playerValue:
-		return DealerWin
+		eval.Result = DealerBlackjack
+	case playerHand.IsCharlie():
+		eval.Result = PlayerCharlie
 	default:
-		return Push
+		switch playerHand.Compare(dealerHand) {
+		case 1:
+			eval.Result = PlayerWin
+		case -1:
+			eval.Result = DealerWin
+		default:
+			eval.Result = Push
+		}
 	}
+
+	switch eval.Result {
+	case PlayerWin, PlayerCharlie:
+		eval.Multiplier = big.NewRat(1, 1) // 1:1 payout
+	case PlayerBlackjack:
+		eval.Multiplier = bg.rules.BlackjackPays().Rat() // 3:2 unless the table pays less
+	case Push:
+		eval.Multiplier = new(big.Rat)
+	case DealerWin, DealerBlackjack:
+		eval.Multiplier = big.NewRat(-1, 1)
+	case Surrendered:
+		eval.Multiplier = big.NewRat(-1, 2) // Half the bet is returned on surrender
+	}
+
+	return eval
 }
 
-// PayoutResults handles payouts for all players
-func (bg *Game) PayoutResults() {
+// EvaluateHand determines the result of a player's hand against the dealer.
+//
+// Deprecated: use Evaluate, which also reports the hand values and payout multiplier.
+func (bg *Game) EvaluateHand(playerHand *Hand) GameResult {
+	return bg.Evaluate(playerHand).Result
+}
+
+// PayoutResults handles payouts for all players. The round may only be settled once
+// the dealer has played, or when the dealer's peek found a blackjack. A hand the chip
+// manager fails to pay is left unsettled, along with the round, so that the payouts
+// can be retried; the chip manager's errors are returned.
+func (bg *Game) PayoutResults() error {
+	if !bg.canSettle() {
+		return fmt.Errorf("the round may not be settled during the %s phase", strings.ToLower(bg.phase.String()))
+	}
+
+	_, span := bg.startSpan(context.Background(), "blackjack.settle")
+
+	var errs []error
+	unsettled := false
 	for _, player := range bg.players {
-		for _, hand := range player.Hands() {
+		for i, hand := range player.Hands() {
 			// Skip hands with no bet or already settled
-			if hand.Bet() == 0 || hand.Winnings() != 0 {
+			if hand.Bet() == 0 || hand.IsSettled() {
 				continue
 			}
 
-			result := bg.EvaluateHand(hand)
-
-			switch result {
-			case PlayerWin:
-				hand.WinBet(1.0) // 1:1 payout
-			case PlayerBlackjack:
-				hand.WinBet(1.5) // 1.5:1 payout for blackjack
-			case Push:
-				hand.PushBet() // Return bet
-			case DealerWin, DealerBlackjack:
-				hand.LoseBet() // Lose bet
+			eval := bg.Evaluate(hand)
+			if !hand.IsSurrendered() {
+				// Surrendered hands were paid when they were surrendered
+				if err := hand.pay(bg.payout.Payout(hand, eval)); err != nil {
+					errs = append(errs, err)
+					unsettled = true
+					continue
+				}
+				if err := player.commitChips(hand.Bet()); err != nil {
+					// The hand was paid, so it is settled all the same
+					errs = append(errs, err)
+				}
 			}
+			hand.markSettled(eval.Result)
+			bg.log().Debug("hand settled", "player", player.Name(), "hand", i, "result", eval.Result.String(), "payout", hand.Winnings())
+			span.AddEvent("hand settled", trace.WithAttributes(
+				attribute.String("blackjack.player", player.Name()),
+				attribute.Int("blackjack.hand", i),
+				attribute.String("blackjack.result", eval.Result.String()),
+				attribute.Int("blackjack.payout", hand.Winnings()),
+			))
 		}
 	}
+	err := errors.Join(errs...)
+	endSpan(span, err)
+	if unsettled {
+		bg.log().Error("failed to settle round", "number", bg.round, "error", err)
+		bg.snapshot()
+		return err
+	}
+
+	bg.dealer.RevealHoleCard()
+	bg.phase = PhaseComplete
+	bg.completeRound()
+	bg.snapshot()
+	return err
+}
+
+// canSettle returns true if the round is ready to be settled: the dealer has played
+// their hand, or the dealer peeked and found a blackjack
+func (bg *Game) canSettle() bool {
+	switch bg.phase {
+	case PhaseDealerTurn:
+		return true
+	case PhasePlayerTurns:
+		return bg.dealer.IsHoleCardRevealed() && bg.dealerPeeksBlackjack()
+	default:
+		return false
+	}
+}
+
+// recordDecision records how long the player took to make a decision, measured
+// from the table's previous decision (or the initial deal)
+func (bg *Game) recordDecision(player *Player) {
+	now := time.Now()
+	if bg.record != nil {
+		if seat := bg.record.seat(player.Name()); seat != nil {
+			seat.DecisionTimes = append(seat.DecisionTimes, now.Sub(bg.lastDecision))
+		}
+	}
+	bg.lastDecision = now
+}
+
+// completeRound finalizes the record of the current round and adds it to the history
+func (bg *Game) completeRound() {
+	if bg.record == nil {
+		return
+	}
+	record := bg.record
+	record.complete(bg.players, bg.dealer, bg.Evaluate)
+	for _, seat := range record.Seats {
+		if player := bg.GetPlayer(seat.Name); player != nil {
+			player.stats.AddRound(seat)
+		}
+	}
+	bg.history = append(bg.history, record)
+	if len(bg.history) > MaxRoundHistory {
+		bg.history = bg.history[len(bg.history)-MaxRoundHistory:]
+	}
+	bg.record = nil
+	bg.log().Info("round completed", "number", record.Number, "duration", record.Duration())
+	bg.emit(Event{Type: EventRoundCompleted, Record: record.Clone()})
+	bg.endRoundSpan()
+}
+
+// History returns copies of the records of the most recently completed rounds,
+// oldest first. Changes made to the copies do not affect the game's history.
+func (bg *Game) History() []*RoundRecord {
+	result := make([]*RoundRecord, len(bg.history))
+	for i, record := range bg.history {
+		result[i] = record.Clone()
+	}
+	return result
+}
+
+// LastRound returns a copy of the record of the most recently completed round, or
+// nil if no round has been completed
+func (bg *Game) LastRound() *RoundRecord {
+	if len(bg.history) == 0 {
+		return nil
+	}
+	return bg.history[len(bg.history)-1].Clone()
 }
 
 // GetGameStatus returns a string representation of the current game state
@@ -0,0 +1,193 @@
+package blackjack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrGameNotFound is returned by a GameStore when no game is stored under an ID
+var ErrGameNotFound = errors.New("game not found")
+
+// GameStore persists saved games. Games are stored in the format written by
+// Game.Save and are keyed by the game's ID.
+type GameStore interface {
+	// SaveGame stores the saved game data under the given ID, replacing any existing game
+	SaveGame(id string, data []byte) error
+	// LoadGame returns the saved game data stored under the given ID, or ErrGameNotFound
+	LoadGame(id string) ([]byte, error)
+	// ListGames returns the IDs of all stored games
+	ListGames() ([]string, error)
+	// DeleteGame removes the game stored under the given ID. Deleting a game that
+	// does not exist is not an error.
+	DeleteGame(id string) error
+}
+
+// SaveTo saves the game to the store under the game's ID
+func (bg *Game) SaveTo(store GameStore) error {
+	var buf bytes.Buffer
+	if err := bg.Save(&buf); err != nil {
+		return err
+	}
+	if err := store.SaveGame(bg.id, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to store game %s: %w", bg.id, err)
+	}
+	return nil
+}
+
+// LoadFrom loads the game stored under the given ID. Options are applied to the
+// loaded game as with Load.
+func LoadFrom(store GameStore, id string, options ...GameOption) (*Game, error) {
+	data, err := store.LoadGame(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game %s: %w", id, err)
+	}
+	return Load(bytes.NewReader(data), options...)
+}
+
+// MemoryGameStore is a GameStore that keeps saved games in memory
+type MemoryGameStore struct {
+	mu    sync.RWMutex
+	games map[string][]byte
+}
+
+// NewMemoryGameStore creates an empty in-memory game store
+func NewMemoryGameStore() *MemoryGameStore {
+	return &MemoryGameStore{games: make(map[string][]byte)}
+}
+
+// SaveGame stores the saved game data under the given ID
+func (s *MemoryGameStore) SaveGame(id string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.games[id] = bytes.Clone(data)
+	return nil
+}
+
+// LoadGame returns the saved game data stored under the given ID
+func (s *MemoryGameStore) LoadGame(id string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.games[id]
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+	return bytes.Clone(data), nil
+}
+
+// ListGames returns the IDs of all stored games, sorted
+func (s *MemoryGameStore) ListGames() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.games))
+	for id := range s.games {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// DeleteGame removes the game stored under the given ID
+func (s *MemoryGameStore) DeleteGame(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.games, id)
+	return nil
+}
+
+// FileGameStore is a GameStore that keeps each saved game in a JSON file in a directory
+type FileGameStore struct {
+	dir string
+}
+
+// gameFileExt is the extension of the files written by a FileGameStore
+const gameFileExt = ".json"
+
+// NewFileGameStore creates a game store in the given directory, creating the directory if needed
+func NewFileGameStore(dir string) (*FileGameStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create game store directory %s: %w", dir, err)
+	}
+	return &FileGameStore{dir: dir}, nil
+}
+
+// path returns the file a game is stored in, rejecting IDs that are not plain file names
+func (s *FileGameStore) path(id string) (string, error) {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return "", fmt.Errorf("invalid game id %q", id)
+	}
+	return filepath.Join(s.dir, id+gameFileExt), nil
+}
+
+// SaveGame stores the saved game data under the given ID. The file is replaced
+// atomically so an interrupted save never leaves a partially written game.
+func (s *FileGameStore) SaveGame(id string, data []byte) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, id+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadGame returns the saved game data stored under the given ID
+func (s *FileGameStore) LoadGame(id string) ([]byte, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrGameNotFound
+	}
+	return data, err
+}
+
+// ListGames returns the IDs of all stored games, sorted
+func (s *FileGameStore) ListGames() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, gameFileExt) {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, gameFileExt))
+	}
+	return ids, nil
+}
+
+// DeleteGame removes the game stored under the given ID
+func (s *FileGameStore) DeleteGame(id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
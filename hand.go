@@ -1,7 +1,9 @@
 package blackjack
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,27 +20,65 @@ const (
 	ActionDouble    ActionType = "double"
 	ActionSplit     ActionType = "split"
 	ActionSurrender ActionType = "surrender"
+	ActionBust      ActionType = "bust"
 )
 
+// ParseActionType returns the action with the name, such as "double"
+func ParseActionType(name string) (ActionType, error) {
+	switch action := ActionType(name); action {
+	case ActionDeal, ActionHit, ActionStand, ActionDouble, ActionSplit, ActionSurrender, ActionBust:
+		return action, nil
+	}
+	return "", fmt.Errorf("unknown action %q", name)
+}
+
+// MarshalText returns the action's name, such as "double"
+func (a ActionType) MarshalText() ([]byte, error) {
+	return []byte(a), nil
+}
+
+// UnmarshalText sets the action from its name, rejecting names that aren't
+// actions. An empty name leaves no action set.
+func (a *ActionType) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*a = ""
+		return nil
+	}
+	action, err := ParseActionType(string(text))
+	if err != nil {
+		return err
+	}
+	*a = action
+	return nil
+}
+
 // Action represents an action taken on a hand
 type Action struct {
 	Type      ActionType  `json:"type"`
 	Card      *cards.Card `json:"card,omitempty"` // Card involved (for deal/hit)
 	Timestamp time.Time   `json:"timestamp"`
 	Details   string      `json:"details,omitempty"` // Additional details about the action
+	Value     int         `json:"value,omitempty"`   // Value of the hand after the action
+	Bet       int         `json:"bet,omitempty"`     // Bet on the hand after the action
 }
 
 // Hand represents a hand of cards in blackjack
 type Hand struct {
-	cards         []cards.Card // cards are the game cards in the hand
-	isSplit       bool         // Whether this hand came from a split
-	isActive      bool         // Whether this hand is still being played
-	isStood       bool         // Whether the player has stood on this hand
-	isSurrendered bool         // Whether the player has surrendered this hand
-	actions       []Action     // All actions taken on this hand
-	bet           int          // The bet amount for this specific hand
-	winnings      int          // The winnings for this specific hand (can be negative for losses)
-	player        *Player      // The player who owns this hand (nil for dealer)
+	cards         []cards.Card  // cards are the game cards in the hand
+	isSplit       bool          // Whether this hand came from a split
+	isActive      bool          // Whether this hand is still being played
+	isStood       bool          // Whether the player has stood on this hand
+	isSurrendered bool          // Whether the player has surrendered this hand
+	actions       []Action      // All actions taken on this hand
+	bets          BetComponents // The wagers on this specific hand
+	winnings      int           // The winnings for this specific hand (can be negative for losses)
+	player        *Player       // The player who owns this hand (nil for dealer)
+	dealer        *Dealer       // The dealer who holds this hand (nil for a player)
+	parent        *Hand         // The hand this hand was split from (nil if not created by a split)
+	spot          int           // The player's spot the hand is played on
+	id            int           // The hand's number among the player's hands for the round, starting at 1
+	result        GameResult    // The outcome of the hand, once it has been settled
+	settledAt     time.Time     // When the hand was settled
 }
 
 // NewDealerHand creates a new dealer hand without a chip manager
@@ -54,12 +94,19 @@ func NewHand(player *Player) *Hand {
 		isActive: true,
 		isStood:  false,
 		actions:  make([]Action, 0, 1),
-		bet:      0,
 		winnings: 0,
 		player:   player,
 	}
 }
 
+// newSpotHand creates a new empty hand for one of the player's spots
+func newSpotHand(player *Player, spot int) *Hand {
+	h := NewHand(player)
+	h.spot = spot
+	h.id = spot + 1
+	return h
+}
+
 // newSplitHand creates a new hand from a split with the initial card
 func newSplitHand(card cards.Card, player *Player) *Hand {
 	h := NewHand(player)
@@ -89,8 +136,13 @@ func (h *Hand) recordAction(actionType ActionType, card *cards.Card, details str
 		Card:      card,
 		Timestamp: time.Now(),
 		Details:   details,
+		Value:     h.Value(),
+		Bet:       h.Bet(),
 	}
 	h.actions = append(h.actions, action)
+	if h.player != nil {
+		h.player.logAction(h, action)
+	}
 }
 
 // RecordAction records an action without a card (like stand, surrender)
@@ -105,55 +157,118 @@ func (h *Hand) Actions() []Action {
 	return result
 }
 
-// ActionSummary returns a string summary of all actions taken on this hand
+// Clone returns a deep copy of the hand, including its cards, actions, and bet,
+// that is unaffected by later changes to the hand. The copy refers to the same
+// player and, for split hands, the same parent hand.
+func (h *Hand) Clone() *Hand {
+	clone := *h
+	clone.cards = h.Cards()
+	clone.actions = copyActions(h.actions)
+	clone.bets = h.bets.clone()
+	return &clone
+}
+
+// copyActions returns a deep copy of the actions, including the cards they refer to
+func copyActions(actions []Action) []Action {
+	result := make([]Action, len(actions))
+	for i, action := range actions {
+		if action.Card != nil {
+			card := *action.Card
+			action.Card = &card
+		}
+		result[i] = action
+	}
+	return result
+}
+
+// ActionSummaryEntry is a single action in a structured summary of a hand, for
+// clients that render action histories their own way
+type ActionSummaryEntry struct {
+	Step    int         `json:"step"`              // Step is the position of the action on the hand, starting at 0
+	Type    ActionType  `json:"type"`              // Type is the type of action
+	Card    *cards.Card `json:"card,omitempty"`    // Card is the card dealt by the action, if any
+	Value   int         `json:"value,omitempty"`   // Value is the value of the hand after the action
+	Bet     int         `json:"bet,omitempty"`     // Bet is the bet on the hand after the action
+	Details string      `json:"details,omitempty"` // Details are additional details about the action
+	Text    string      `json:"text"`              // Text describes the action using the current message catalog
+}
+
+// ActionEntries returns a structured summary of all actions taken on this hand
+func (h *Hand) ActionEntries() []ActionSummaryEntry {
+	entries := make([]ActionSummaryEntry, len(h.actions))
+	for i, action := range copyActions(h.actions) {
+		entries[i] = ActionSummaryEntry{
+			Step:    i,
+			Type:    action.Type,
+			Card:    action.Card,
+			Value:   action.Value,
+			Bet:     action.Bet,
+			Details: action.Details,
+			Text:    action.Text(),
+		}
+	}
+	return entries
+}
+
+// ActionSummary returns a string summary of all actions taken on this hand, using
+// the current message catalog
 func (h *Hand) ActionSummary() string {
 	if len(h.actions) == 0 {
-		return "No actions"
+		return Message(MsgNoActions)
 	}
 
 	var summary strings.Builder
 	for i, action := range h.actions {
 		if i > 0 {
-			summary.WriteString(", ")
+			summary.WriteString(Message(MsgActionSeparator))
 		}
+		summary.WriteString(action.Text())
+	}
 
-		switch action.Type {
-		case ActionDeal:
-			if action.Card != nil {
-				summary.WriteString(fmt.Sprintf("dealt %s", action.Card))
-			} else {
-				summary.WriteString("dealt")
-			}
-		case ActionHit:
-			if action.Card != nil {
-				summary.WriteString(fmt.Sprintf("hit %s", action.Card))
-			} else {
-				summary.WriteString("hit")
-			}
-		case ActionStand:
-			summary.WriteString("stand")
-		case ActionDouble:
-			if action.Card != nil {
-				summary.WriteString(fmt.Sprintf("double %s", action.Card))
-			} else {
-				summary.WriteString("double")
-			}
-		case ActionSplit:
-			summary.WriteString("split")
-		case ActionSurrender:
-			summary.WriteString("surrender")
-		default:
-			summary.WriteString(string(action.Type))
-		}
+	return summary.String()
+}
 
-		if action.Details != "" {
-			summary.WriteString(" (")
-			summary.WriteString(action.Details)
-			summary.WriteString(")")
+// Text returns a description of the action using the current message catalog
+func (a Action) Text() string {
+	var text string
+	switch a.Type {
+	case ActionDeal:
+		if a.Card != nil {
+			text = Message(MsgActionDealCard, a.Card)
+		} else {
+			text = Message(MsgActionDeal)
+		}
+	case ActionHit:
+		if a.Card != nil {
+			text = Message(MsgActionHitCard, a.Card)
+		} else {
+			text = Message(MsgActionHit)
 		}
+	case ActionStand:
+		text = Message(MsgActionStand)
+	case ActionDouble:
+		if a.Card != nil {
+			text = Message(MsgActionDoubleCard, a.Card)
+		} else {
+			text = Message(MsgActionDouble)
+		}
+	case ActionSplit:
+		text = Message(MsgActionSplit)
+	case ActionSurrender:
+		text = Message(MsgActionSurrender)
+	case ActionBust:
+		text = Message(MsgActionBust)
+	default:
+		text = string(a.Type)
 	}
 
-	return summary.String()
+	if a.Card != nil && a.Value > 0 {
+		text = Message(MsgActionWithValue, text, a.Value)
+	}
+	if a.Details != "" {
+		text = Message(MsgActionWithDetails, text, a.Details)
+	}
+	return text
 }
 
 // Cards returns a copy of the cards in the hand
@@ -165,10 +280,15 @@ func (h *Hand) Cards() []cards.Card {
 
 // Value calculates the blackjack value of the hand
 func (h *Hand) Value() int {
+	return cardsValue(h.cards)
+}
+
+// cardsValue calculates the blackjack value of a set of cards
+func cardsValue(hand []cards.Card) int {
 	value := 0
 	aces := 0
 
-	for _, card := range h.cards {
+	for _, card := range hand {
 		rank := card.Rank
 		switch rank {
 		case cards.Jack, cards.Queen, cards.King:
@@ -190,37 +310,102 @@ func (h *Hand) Value() int {
 	return value
 }
 
-// PlaceBet places a bet for the player's current hand
+// PlaceBet places a bet for the player's current hand. If the player is seated in
+// a game, the bet must also be allowed by the game's rules and current phase, and
+// only one bet may be placed on the hand each round.
 func (h *Hand) PlaceBet(amount int) error {
+	return h.placeBet(context.Background(), amount)
+}
+
+// placeBet places a bet for the player's current hand, passing ctx to the chip manager
+func (h *Hand) placeBet(ctx context.Context, amount int) error {
 	if amount <= 0 {
 		return fmt.Errorf("bet must be positive")
 	}
-	if !h.player.chipManager.HasEnoughChips(amount) {
-		return fmt.Errorf("insufficient chips: have %d, need %d", h.player.chipManager.GetChips(), amount)
+	if game := h.player.game; game != nil {
+		if h.player.IsWaiting() {
+			return fmt.Errorf("player %s will be dealt in next round", h.player.Name())
+		}
+		if err := game.validateBet(amount); err != nil {
+			return err
+		}
+		if bet := h.Bet(); bet > 0 {
+			return fmt.Errorf("player %s has already bet %d on this spot", h.player.Name(), bet)
+		}
+	}
+	enough, err := h.player.hasEnoughChips(ctx, amount)
+	if err != nil {
+		return fmt.Errorf("failed to check chips for %s: %w", h.player.Name(), err)
+	}
+	if !enough {
+		return fmt.Errorf("insufficient chips: have %d, need %d", h.player.Chips(), amount)
 	}
 
 	// Set bet on current hand and deduct from chips
 	h.SetBet(amount)
-	return h.player.chipManager.DeductChips(amount)
+	return h.player.reserveChips(ctx, amount, ChipReasonBet)
 }
 
-// WinBet adds winnings to the player's chips for the current hand
-func (h *Hand) WinBet(multiplier float64) {
-	winnings := int(float64(h.Bet()) * multiplier)
-	totalPayout := h.Bet() + winnings
-	h.player.chipManager.AddChips(totalPayout)
-	h.SetWinnings(winnings)
+// WinBet adds winnings to the player's chips for the current hand. The winnings are
+// the bet paid at the ratio worked out exactly, so a fractional payout such as 7:5
+// pays every whole chip owed; only a fraction of a chip is dropped. If the chip
+// manager fails to pay the hand, no winnings are recorded and the error is returned.
+func (h *Hand) WinBet(ratio PayoutRatio) error {
+	return h.settle(exactPayout(h.Bet(), ratio.Rat(), RoundDown))
 }
 
 // LoseBet removes the player's bet for the current hand (already deducted when placed)
-func (h *Hand) LoseBet() {
-	h.SetWinnings(-h.Bet()) // Record the loss
+func (h *Hand) LoseBet() error {
+	return h.settle(-h.Bet())
+}
+
+// PushBet returns the bet to the player for the current hand (tie). If the chip
+// manager fails to return the bet, the error is returned.
+func (h *Hand) PushBet() error {
+	return h.settle(0)
+}
+
+// settle pays out the hand given the net amount won (or lost, if negative) and
+// commits its bet
+func (h *Hand) settle(net int) error {
+	if err := h.pay(net); err != nil {
+		return err
+	}
+	return h.player.commitChips(h.Bet())
 }
 
-// PushBet returns the bet to the player for the current hand (tie)
-func (h *Hand) PushBet() {
-	h.player.chipManager.AddChips(h.Bet())
-	h.SetWinnings(0) // No win or loss
+// pay pays out the hand given the net amount won (or lost, if negative). The bet
+// is returned along with any winnings, less any amount lost. The winnings are only
+// recorded once the chip manager has paid them.
+func (h *Hand) pay(net int) error {
+	if payout := h.Bet() + net; payout > 0 {
+		if err := h.player.creditChips(context.Background(), payout, ChipReasonPayout); err != nil {
+			return fmt.Errorf("failed to pay %d to %s: %w", payout, h.player.Name(), err)
+		}
+	}
+	h.SetWinnings(net)
+	return nil
+}
+
+// Result returns the outcome of the hand. It is zero until the hand is settled.
+func (h *Hand) Result() GameResult {
+	return h.result
+}
+
+// IsSettled returns true if the hand has been settled at the end of the round
+func (h *Hand) IsSettled() bool {
+	return h.result != 0
+}
+
+// SettledAt returns when the hand was settled, or the zero time if it hasn't been
+func (h *Hand) SettledAt() time.Time {
+	return h.settledAt
+}
+
+// markSettled records the outcome of the hand
+func (h *Hand) markSettled(result GameResult) {
+	h.result = result
+	h.settledAt = time.Now()
 }
 
 // IsBusted returns true if the hand value is over 21
@@ -233,25 +418,86 @@ func (h *Hand) IsBlackjack() bool {
 	return len(h.cards) == 2 && h.Value() == 21 && !h.IsSplit()
 }
 
+// IsCharlie returns true if the table plays a Charlie rule and the hand has reached
+// the number of cards that wins without busting
+func (h *Hand) IsCharlie() bool {
+	charlie := h.rules().CharlieCards
+	return charlie > 0 && len(h.cards) >= charlie && !h.IsBusted()
+}
+
+// Compare compares the hand with another using the rules of blackjack, with the
+// hand taking the player's side. It returns 1 if the hand wins, -1 if it loses,
+// and 0 if the hands push. A busted hand loses even if the other hand also busted,
+// and a natural blackjack beats any other 21.
+func (h *Hand) Compare(other *Hand) int {
+	blackjack, otherBlackjack := h.IsBlackjack(), other.IsBlackjack()
+	value, otherValue := h.Value(), other.Value()
+	switch {
+	case blackjack && otherBlackjack:
+		return 0
+	case blackjack:
+		return 1
+	case otherBlackjack:
+		return -1
+	case value > 21:
+		return -1
+	case otherValue > 21:
+		return 1
+	case value > otherValue:
+		return 1
+	case value < otherValue:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// IsPair returns true if the hand is two cards of the same rank
+func (h *Hand) IsPair() bool {
+	return len(h.cards) == 2 && h.cards[0].Rank == h.cards[1].Rank
+}
+
+// IsTwentyOne returns true if the hand is worth 21, whether or not it is a blackjack
+func (h *Hand) IsTwentyOne() bool {
+	return h.Value() == 21
+}
+
+// Ranks returns the ranks of the cards in the hand, in the order they were dealt
+func (h *Hand) Ranks() []cards.Rank {
+	ranks := make([]cards.Rank, len(h.cards))
+	for i, card := range h.cards {
+		ranks[i] = card.Rank
+	}
+	return ranks
+}
+
 // IsSoft returns true if the hand contains an ace counted as 11
 func (h *Hand) IsSoft() bool {
-	value := 0
-	hasAce := false
+	_, _, isSoft := h.Values()
+	return isSoft
+}
 
+// Values returns the hand's hard value, counting every ace as 1, and its soft
+// value, counting one ace as 11. If the soft value would be over 21, or the hand
+// has no aces, the soft value is the same as the hard value and isSoft is false.
+// For example, an ace and a six return 7, 17, and true.
+func (h *Hand) Values() (hard int, soft int, isSoft bool) {
+	hasAce := false
 	for _, card := range h.cards {
-		rank := card.Rank
-		switch rank {
+		switch card.Rank {
 		case cards.Jack, cards.Queen, cards.King:
-			value += 10
+			hard += 10
 		case cards.Ace:
 			hasAce = true
-			value += 11
+			hard++
 		default:
-			value += int(rank)
+			hard += int(card.Rank)
 		}
 	}
-
-	return hasAce && value <= 21
+	if hasAce && hard+10 <= 21 {
+		return hard, hard + 10, true
+	}
+	return hard, hard, false
 }
 
 // IsSplit returns true if this hand was created by a split.
@@ -259,6 +505,52 @@ func (h *Hand) IsSplit() bool {
 	return h.isSplit
 }
 
+// Spot returns the index of the player's spot the hand is played on. Hands split
+// from a spot share its index.
+func (h *Hand) Spot() int {
+	return h.spot
+}
+
+// Parent returns the hand this hand was split from, or nil if the hand was not
+// created by a split
+func (h *Hand) Parent() *Hand {
+	return h.parent
+}
+
+// ID returns the hand's number among the player's hands for the round, starting at
+// 1. Hands created by a split are numbered after the player's other hands.
+func (h *Hand) ID() int {
+	return h.id
+}
+
+// ParentID returns the ID of the hand this hand was split from, or 0 if the hand
+// was not created by a split
+func (h *Hand) ParentID() int {
+	if h.parent == nil {
+		return 0
+	}
+	return h.parent.id
+}
+
+// Root returns the hand dealt to the spot that this hand was split from, following
+// its parents, or the hand itself if it was not created by a split
+func (h *Hand) Root() *Hand {
+	root := h
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// Label returns the hand's name, noting the hand it was split from, such as
+// "Hand 3 (split from Hand 1)", using the current message catalog
+func (h *Hand) Label() string {
+	if h.parent != nil {
+		return Message(MsgHandSplitFrom, h.id, h.parent.id)
+	}
+	return Message(MsgHandLabel, h.id)
+}
+
 // Count returns the number of cards in the hand
 func (h *Hand) Count() int {
 	return len(h.cards)
@@ -270,21 +562,27 @@ func (h *Hand) Clear() {
 	h.isSplit = false
 	h.isActive = true
 	h.isStood = false
-	h.bet = 0
+	h.isSurrendered = false
+	h.parent = nil
+	h.actions = h.actions[:0]
+	h.bets = BetComponents{}
 	h.winnings = 0
+	h.result = 0
+	h.settledAt = time.Time{}
 }
 
 // Bet returns the bet amount for this hand
 func (h *Hand) Bet() int {
-	return h.bet
+	return h.bets.Main()
 }
 
 // SetBet sets the bet amount for this hand
 func (h *Hand) SetBet(amount int) {
-	h.bet = amount
+	h.bets = BetComponents{Original: amount}
 }
 
-// Winnings returns the winnings for this hand (can be negative for losses)
+// Winnings returns the winnings for this hand (can be negative for losses). Use
+// WinningsBreakdown to see which wagers they came from.
 func (h *Hand) Winnings() int {
 	return h.winnings
 }
@@ -313,11 +611,26 @@ func (h *Hand) SetActive(active bool) {
 func (h *Hand) Hit(card cards.Card) {
 	// Use AddCardWithAction to specify this is a hit
 	h.AddCardWithAction(card, ActionHit, "player hit")
-	if h.IsSplit() && h.cards[1].Rank == cards.Ace {
-		// If the hand is a split aces hand, automatically stand after one hit
+	h.standIfComplete()
+}
+
+// dealSplitCard deals the second card to a hand created by a split
+func (h *Hand) dealSplitCard(card cards.Card) {
+	h.AddCardWithAction(card, ActionDeal, "split second card")
+	h.standIfComplete()
+}
+
+// standIfComplete stands the hand if no further cards may be taken
+func (h *Hand) standIfComplete() {
+	if h.isStood {
+		return
+	}
+	if h.isSplitAces() && !h.rules().HitSplitAces && !h.CanSplit() {
+		// Split aces receive only one card, unless it is another ace that may be resplit
 		h.Stand()
+		return
 	}
-	if h.Value() == 21 {
+	if h.Value() == 21 || h.IsCharlie() {
 		h.Stand()
 	}
 }
@@ -339,27 +652,70 @@ func (h *Hand) Stand() {
 	h.RecordAction(ActionStand, "")
 }
 
-// CanDoubleDown returns true if the hand can be doubled down
+// CanHit returns true if the table rules allow the hand to take another card
+func (h *Hand) CanHit() bool {
+	return h.rules().AllowsHit(h)
+}
+
+// CanDoubleDown returns true if the table rules allow the hand to be doubled down
+// and the player has the chips to do so
 func (h *Hand) CanDoubleDown() bool {
-	return len(h.cards) == 2 && h.player.chipManager != nil && h.player.chipManager.HasEnoughChips(h.bet)
+	return h.canDoubleDown(context.Background())
+}
+
+// canDoubleDown returns true if the hand may be doubled down, passing ctx to the
+// chip manager
+func (h *Hand) canDoubleDown(ctx context.Context) bool {
+	if !h.rules().AllowsDouble(h) {
+		return false
+	}
+	enough, err := h.player.hasEnoughChips(ctx, h.bets.Original)
+	return enough && err == nil
 }
 
-// DoubleDown performs the double down action on the hand
+// DoubleDown doubles the bet on the hand and stands it. The hand's one additional
+// card must then be dealt with DoubleDownHit; DoubleDownWith does both at once.
 func (h *Hand) DoubleDown() error {
-	if !h.CanDoubleDown() {
+	if err := h.doubleBet(context.Background()); err != nil {
+		return err
+	}
+	h.Stand()
+
+	return nil
+}
+
+// DoubleDownWith doubles the bet on the hand, deals it the card, and stands it in
+// a single operation. The hand is unchanged if it may not be doubled down.
+func (h *Hand) DoubleDownWith(card cards.Card) error {
+	return h.doubleDownWith(context.Background(), card)
+}
+
+// doubleDownWith doubles down on the hand with the card like DoubleDownWith,
+// passing ctx to the chip manager
+func (h *Hand) doubleDownWith(ctx context.Context, card cards.Card) error {
+	if err := h.doubleBet(ctx); err != nil {
+		return err
+	}
+	h.DoubleDownHit(card)
+	h.Stand()
+
+	return nil
+}
+
+// doubleBet reserves the chips for a double down and doubles the bet on the hand
+func (h *Hand) doubleBet(ctx context.Context) error {
+	if !h.canDoubleDown(ctx) {
 		return fmt.Errorf("cannot double down on this hand")
 	}
 
 	// Deduct additional bet from chip manager
-	err := h.player.chipManager.DeductChips(h.bet)
+	err := h.player.reserveChips(ctx, h.bets.Original, ChipReasonDoubleDown)
 	if err != nil {
 		return fmt.Errorf("failed to deduct chips for double down: %v", err)
 	}
 
-	h.bet *= 2
-	h.Stand()
-	h.RecordAction(ActionDouble, fmt.Sprintf("bet increased from %d to %d", h.bet/2, h.bet))
-
+	h.bets.Double = h.bets.Original
+	h.RecordAction(ActionDouble, fmt.Sprintf("bet increased from %d to %d", h.bets.Original, h.Bet()))
 	return nil
 }
 
@@ -368,33 +724,63 @@ func (h *Hand) DoubleDownHit(card cards.Card) {
 	h.AddCardWithAction(card, ActionDouble, "double down card")
 }
 
+// awaitingDoubleDownCard returns true if the hand was doubled down but has not yet
+// been dealt its one additional card
+func (h *Hand) awaitingDoubleDownCard() bool {
+	if h.bets.Double == 0 {
+		return false
+	}
+	return !slices.ContainsFunc(h.actions, func(action Action) bool {
+		return action.Type == ActionDouble && action.Card != nil
+	})
+}
+
 // CanSplit returns true if the hand can be split (two cards of same rank)
 func (h *Hand) CanSplit() bool {
-	if len(h.player.Hands()) >= 4 ||
-		len(h.cards) != 2 ||
-		!h.player.chipManager.HasEnoughChips(h.Bet()) {
+	return h.canSplit(context.Background())
+}
+
+// canSplit returns true if the hand can be split, passing ctx to the chip manager
+func (h *Hand) canSplit(ctx context.Context) bool {
+	if h.player.spotHandCount(h.spot) >= h.rules().maxSplitHands() || len(h.cards) != 2 {
+		return false
+	}
+	if h.isSplitAces() && !h.rules().ResplitAces {
 		return false
 	}
-	return h.cards[0].Rank == h.cards[1].Rank
+	if enough, err := h.player.hasEnoughChips(ctx, h.Bet()); !enough || err != nil {
+		return false
+	}
+	return h.IsPair()
+}
+
+// isSplitAces returns true if the hand was created by splitting a pair of aces
+func (h *Hand) isSplitAces() bool {
+	return h.isSplit && len(h.cards) > 0 && h.cards[0].Rank == cards.Ace
 }
 
 // Split splits the player's hand into two hands
 func (h *Hand) Split() error {
-	if !h.CanSplit() {
+	return h.split(context.Background())
+}
+
+// split splits the hand like Split, passing ctx to the chip manager
+func (h *Hand) split(ctx context.Context) error {
+	if !h.canSplit(ctx) {
 		return fmt.Errorf("cannot split")
 	}
 
-	// Record split action before splitting
-	h.RecordAction(ActionSplit, fmt.Sprintf("split into %d hands", len(h.player.Hands())+1))
+	// Reserve the new hand's bet before changing the hand, so that a failure leaves
+	// the hand as it was
+	currentBet := h.Bet()
+	if err := h.player.reserveChips(ctx, currentBet, ChipReasonSplit); err != nil {
+		return fmt.Errorf("failed to deduct chips for split: %w", err)
+	}
 
 	// Use the Hand's SplitHand method to get the new hand
 	newHand := h.splitHand()
-	if newHand == nil {
-		return fmt.Errorf("split failed")
-	}
 
 	// Set the same bet on the new hand before adding to slice
-	currentBet := h.Bet()
 	newHand.SetBet(currentBet)
 
 	// Record split action on the new hand too
@@ -402,18 +788,12 @@ func (h *Hand) Split() error {
 
 	// Add the new hand to the player's hands
 	h.player.hands = append(h.player.hands, newHand)
-
-	// Deduct from chips for the new hand's bet
-	err := h.player.chipManager.DeductChips(currentBet)
-	return err
+	return nil
 }
 
-// splitHand splits the hand into two hands
+// splitHand splits the hand into two hands. The caller must have checked that the
+// hand can be split.
 func (h *Hand) splitHand() *Hand {
-	if !h.CanSplit() {
-		return nil
-	}
-
 	// Take the second card for the new hand
 	secondCard := h.cards[1]
 	h.cards = h.cards[:1]
@@ -421,8 +801,14 @@ func (h *Hand) splitHand() *Hand {
 	// Mark this hand as split
 	h.isSplit = true
 
+	// Record the split once the hand holds a single card, before the new hand is dealt
+	h.RecordAction(ActionSplit, fmt.Sprintf("split into %d hands", len(h.player.Hands())+1))
+
 	// Create new hand with the second card
 	newHand := newSplitHand(secondCard, h.player)
+	newHand.parent = h
+	newHand.spot = h.spot
+	newHand.id = len(h.player.hands) + 1
 
 	return newHand
 }
@@ -432,26 +818,65 @@ func (h *Hand) IsSurrendered() bool {
 	return h.isSurrendered
 }
 
-// CanSurrender returns true if the player can surrender (typically only on first two cards)
+// CanSurrender returns true if the player can surrender the hand, which is only
+// allowed on the first two cards before the hand has been acted on
 func (h *Hand) CanSurrender() bool {
-	return len(h.player.Hands()) == 1 && h.Count() == 2 && !h.IsStood() && !h.IsBusted()
+	if rules := h.rules(); rules.NoSurrender || (h.isSplit && !rules.SurrenderAfterSplit) {
+		return false
+	}
+	return h.player != nil && h.Count() == 2 && !h.IsStood() && !h.IsBusted() && !h.IsSurrendered() && !h.actedOn()
+}
+
+// actedOn returns true if the player has hit, stood, doubled down, or surrendered
+// the hand. Splitting a hand does not count, as each split hand is played anew.
+func (h *Hand) actedOn() bool {
+	for _, action := range h.actions {
+		switch action.Type {
+		case ActionHit, ActionStand, ActionDouble, ActionSurrender:
+			return true
+		}
+	}
+	return false
+}
+
+// rules returns the rules of the game the hand is played in, or the default rules
+// if the hand is not part of a game
+func (h *Hand) rules() Rules {
+	if h.player != nil && h.player.game != nil {
+		return h.player.game.rules
+	}
+	return DefaultRules()
 }
 
-// Surrender allows the player to forfeit their hand and lose half their bet
-func (h *Hand) Surrender() {
+// Surrender allows the player to forfeit their hand and lose half their bet. If the
+// chip manager fails to return half the bet, the hand is not surrendered.
+func (h *Hand) Surrender() error {
+	return h.surrender(context.Background())
+}
+
+// surrender surrenders the hand like Surrender, passing ctx to the chip manager
+func (h *Hand) surrender(ctx context.Context) error {
+	if !h.CanSurrender() {
+		return fmt.Errorf("cannot surrender this hand")
+	}
+
 	currentBet := h.Bet()
 	halfBet := currentBet / 2
-	h.player.chipManager.AddChips(halfBet)
-	h.SetWinnings(-halfBet) // Record the loss of half bet
+	if err := h.player.releaseChips(ctx, halfBet, ChipReasonSurrender); err != nil {
+		return err
+	}
+	h.SetWinnings(halfBet - currentBet) // Record the loss of the chips not returned
 	h.RecordAction(ActionSurrender, fmt.Sprintf("received %d chips back", halfBet))
 	h.Stand()
 	h.isSurrendered = true
+
+	return h.player.commitChips(currentBet - halfBet)
 }
 
 // String returns a string representation of the hand
 func (h *Hand) String() string {
 	if len(h.cards) == 0 {
-		return "Empty hand"
+		return Message(MsgHandEmpty)
 	}
 
 	var cardStrings []string
@@ -461,48 +886,35 @@ func (h *Hand) String() string {
 
 	splitText := ""
 	if h.isSplit {
-		splitText = " (Split)"
+		splitText = Message(MsgHandSplit)
 	}
 
-	return fmt.Sprintf("[%s] (Value: %d)%s", strings.Join(cardStrings, ", "), h.Value(), splitText)
+	return Message(MsgHandValue, strings.Join(cardStrings, ", "), h.Value()) + splitText
 }
 
-// StringHidden returns a string representation with the first card hidden (for dealer)
-func (h *Hand) StringHidden() string {
-	if len(h.cards) == 0 {
-		return "Empty hand"
-	}
-	if len(h.cards) == 1 {
-		return "[Hidden]"
+// VisibleValue returns the value of the hand excluding its second card, the
+// dealer's hole card
+func (h *Hand) VisibleValue() int {
+	if len(h.cards) < 2 {
+		return cardsValue(h.cards)
 	}
+	return cardsValue(append([]cards.Card{h.cards[0]}, h.cards[2:]...))
+}
 
-	var cardStrings []string
-	cardStrings = append(cardStrings, "Hidden")
-	for i := 1; i < len(h.cards); i++ {
-		cardStrings = append(cardStrings, h.cards[i].String())
+// StringHidden returns a string representation with the second (hole) card hidden (for dealer)
+func (h *Hand) StringHidden() string {
+	if len(h.cards) == 0 {
+		return Message(MsgHandEmpty)
 	}
 
-	// Calculate visible value (excluding first card)
-	visibleValue := 0
-	aces := 0
-	for i := 1; i < len(h.cards); i++ {
-		rank := h.cards[i].Rank
-		switch rank {
-		case cards.Jack, cards.Queen, cards.King:
-			visibleValue += 10
-		case cards.Ace:
-			aces++
-			visibleValue += 11
-		default:
-			visibleValue += int(rank)
+	cardStrings := make([]string, 0, len(h.cards))
+	for i, card := range h.cards {
+		if i == 1 {
+			cardStrings = append(cardStrings, Message(MsgHandHidden))
+			continue
 		}
+		cardStrings = append(cardStrings, card.String())
 	}
 
-	// Adjust for aces
-	for aces > 0 && visibleValue > 21 {
-		visibleValue -= 10
-		aces--
-	}
-
-	return fmt.Sprintf("[%s] (Visible Value: %d)", strings.Join(cardStrings, ", "), visibleValue)
+	return Message(MsgHandVisibleValue, strings.Join(cardStrings, ", "), h.VisibleValue())
 }
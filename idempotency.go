@@ -0,0 +1,180 @@
+package blackjack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	MaxIdempotencyKeys = 1024 // MaxIdempotencyKeys is the number of idempotency keys remembered by a game
+)
+
+// idempotencyKey is an idempotency key as used by one player. Each player has their
+// own keys, so that one player's key never blocks another's request.
+type idempotencyKey struct {
+	player string
+	key    string
+}
+
+// idempotentResult is the remembered result of a keyed request
+type idempotentResult struct {
+	request string // request identifies what was requested with the key
+	outcome ActionOutcome
+	err     error
+}
+
+// Act performs an action (hit, stand, double, split, or surrender) on the player's
+// current hand
+func (bg *Game) Act(playerName string, action ActionType) (ActionOutcome, error) {
+	return bg.ActContext(context.Background(), playerName, action)
+}
+
+// ActContext performs an action like Act. The player's chip manager is given ctx,
+// so that cancelling ctx cancels any chips the action reserves or returns. If
+// tracing is enabled, the action's span is parented by the span in ctx.
+func (bg *Game) ActContext(ctx context.Context, playerName string, action ActionType) (ActionOutcome, error) {
+	_, span := bg.startSpan(ctx, "blackjack.action",
+		attribute.String("blackjack.player", playerName),
+		attribute.String("blackjack.action", string(action)),
+	)
+	outcome, err := bg.act(ctx, playerName, action)
+	span.SetAttributes(
+		attribute.Int("blackjack.hand", outcome.HandIndex),
+		attribute.Bool("blackjack.turn_ended", outcome.TurnEnded),
+	)
+	endSpan(span, err)
+	return outcome, err
+}
+
+// act performs an action on the player's current hand, passing ctx to the player's
+// chip manager
+func (bg *Game) act(ctx context.Context, playerName string, action ActionType) (ActionOutcome, error) {
+	switch action {
+	case ActionHit:
+		return bg.playerHit(playerName)
+	case ActionStand:
+		return bg.PlayerStand(playerName)
+	case ActionDouble:
+		return bg.playerDoubleDown(ctx, playerName)
+	case ActionSplit:
+		return bg.playerSplit(ctx, playerName)
+	case ActionSurrender:
+		return bg.playerSurrender(ctx, playerName)
+	default:
+		return ActionOutcome{}, fmt.Errorf("%s is not a player action", action)
+	}
+}
+
+// ActWithKey performs an action like Act, but only once for a given idempotency
+// key. Repeating a request with the same key, such as when a client retries after
+// a network failure, returns the original result without acting again.
+func (bg *Game) ActWithKey(key string, playerName string, action ActionType) (ActionOutcome, error) {
+	return bg.ActWithKeyContext(context.Background(), key, playerName, action)
+}
+
+// ActWithKeyContext performs an action like ActWithKey, parenting the action's span
+// by the span in ctx as with ActContext
+func (bg *Game) ActWithKeyContext(ctx context.Context, key string, playerName string, action ActionType) (ActionOutcome, error) {
+	request := fmt.Sprintf("act:%s:%s", playerName, action)
+	return bg.idempotent(playerName, key, request, func() (ActionOutcome, error) {
+		return bg.ActContext(ctx, playerName, action)
+	})
+}
+
+// PlaceBet places a bet on one of the player's spots
+func (bg *Game) PlaceBet(playerName string, spot int, amount int) error {
+	return bg.PlaceBetContext(context.Background(), playerName, spot, amount)
+}
+
+// PlaceBetContext places a bet like PlaceBet. The player's chip manager is given
+// ctx, so that cancelling ctx cancels the bet's reservation. If tracing is enabled,
+// the bet's span is parented by the span in ctx.
+func (bg *Game) PlaceBetContext(ctx context.Context, playerName string, spot int, amount int) error {
+	_, span := bg.startSpan(ctx, "blackjack.bet",
+		attribute.String("blackjack.player", playerName),
+		attribute.Int("blackjack.spot", spot),
+		attribute.Int("blackjack.amount", amount),
+	)
+	err := bg.placeBet(ctx, playerName, spot, amount)
+	endSpan(span, err)
+	return err
+}
+
+// placeBet places a bet on one of the player's spots, passing ctx to the player's
+// chip manager
+func (bg *Game) placeBet(ctx context.Context, playerName string, spot int, amount int) error {
+	player := bg.GetPlayer(playerName)
+	if player == nil {
+		return fmt.Errorf("player %s not found", playerName)
+	}
+	for _, hand := range player.hands {
+		if hand.spot == spot {
+			return hand.placeBet(ctx, amount)
+		}
+	}
+	return fmt.Errorf("player %s does not have spot %d", playerName, spot+1)
+}
+
+// PlaceBetWithKey places a bet like PlaceBet, but only once for a given
+// idempotency key. Repeating a request with the same key returns the original result.
+func (bg *Game) PlaceBetWithKey(key string, playerName string, spot int, amount int) error {
+	return bg.PlaceBetWithKeyContext(context.Background(), key, playerName, spot, amount)
+}
+
+// PlaceBetWithKeyContext places a bet like PlaceBetWithKey, parenting the bet's span
+// by the span in ctx as with PlaceBetContext
+func (bg *Game) PlaceBetWithKeyContext(ctx context.Context, key string, playerName string, spot int, amount int) error {
+	request := fmt.Sprintf("bet:%s:%d:%d", playerName, spot, amount)
+	_, err := bg.idempotent(playerName, key, request, func() (ActionOutcome, error) {
+		return ActionOutcome{HandIndex: spot}, bg.PlaceBetContext(ctx, playerName, spot, amount)
+	})
+	return err
+}
+
+// idempotent runs fn unless the player has already used the key, in which case
+// the remembered result is returned. A key may not be reused for a different
+// request. The keys are saved with the game, so a request retried after the game
+// is recovered from a snapshot is not run again.
+func (bg *Game) idempotent(playerName string, key string, request string, fn func() (ActionOutcome, error)) (ActionOutcome, error) {
+	if key == "" {
+		return fn()
+	}
+	id := idempotencyKey{player: playerName, key: key}
+	if result, ok := bg.idempotencyResults[id]; ok {
+		if result.request != request {
+			return ActionOutcome{}, fmt.Errorf("idempotency key %q was already used for a different request", key)
+		}
+		return result.outcome, result.err
+	}
+
+	phase := bg.phase
+	outcome, err := fn()
+	if errors.Is(err, ErrRateLimited) {
+		// The request was never processed, so allow it to be retried with the same key
+		return outcome, err
+	}
+	bg.rememberResult(id, idempotentResult{request: request, outcome: outcome, err: err})
+	if bg.phase != phase {
+		// The snapshot taken when the phase changed doesn't hold the key yet
+		bg.snapshot()
+	}
+
+	return outcome, err
+}
+
+// rememberResult remembers the result of a keyed request, forgetting the oldest
+// key once more than MaxIdempotencyKeys are remembered
+func (bg *Game) rememberResult(id idempotencyKey, result idempotentResult) {
+	if bg.idempotencyResults == nil {
+		bg.idempotencyResults = make(map[idempotencyKey]idempotentResult)
+	}
+	bg.idempotencyResults[id] = result
+	bg.idempotencyKeys = append(bg.idempotencyKeys, id)
+	if len(bg.idempotencyKeys) > MaxIdempotencyKeys {
+		delete(bg.idempotencyResults, bg.idempotencyKeys[0])
+		bg.idempotencyKeys = bg.idempotencyKeys[1:]
+	}
+}
@@ -0,0 +1,166 @@
+package blackjack
+
+import (
+	"sync"
+	"time"
+)
+
+// ChipTransaction is a single change to a player's chips recorded by a ChipLedger
+type ChipTransaction struct {
+	Amount    int        `json:"amount"`             // Amount is the change in chips (negative for a deduction)
+	Balance   int        `json:"balance"`            // Balance is the chip count after the change
+	Reason    ChipReason `json:"reason,omitempty"`   // Reason is why the chips changed (empty if changed outside of a game)
+	RoundID   int64      `json:"round_id,omitempty"` // RoundID is the round the change was made in, if any
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+// ChipTransactionFilter selects transactions from a ChipLedger. Zero-valued fields
+// match every transaction.
+type ChipTransactionFilter struct {
+	Reason  ChipReason // Reason matches transactions with the reason
+	RoundID int64      // RoundID matches transactions made in the round
+	Since   time.Time  // Since matches transactions made at or after the time
+	Until   time.Time  // Until matches transactions made before the time
+}
+
+// matches returns true if the transaction is selected by the filter
+func (f ChipTransactionFilter) matches(tx ChipTransaction) bool {
+	return (f.Reason == "" || tx.Reason == f.Reason) &&
+		(f.RoundID == 0 || tx.RoundID == f.RoundID) &&
+		(f.Since.IsZero() || !tx.Timestamp.Before(f.Since)) &&
+		(f.Until.IsZero() || tx.Timestamp.Before(f.Until))
+}
+
+// ChipLedger is a ChipManager that records every change made to the chips of the
+// ChipManager it wraps. When used by a player seated in a game, each transaction
+// is labeled with the reason for the change and the round it was made in. Bets
+// are deducted when placed rather than held in escrow, even if the wrapped
+// ChipManager supports it.
+type ChipLedger struct {
+	mu           sync.Mutex
+	cm           ChipManager
+	transactions []ChipTransaction
+	reason       ChipReason // reason labels the next change
+	roundID      int64      // roundID labels the next change
+}
+
+// NewChipLedger creates a ledger recording the changes made through it to the
+// chip manager's chips
+func NewChipLedger(cm ChipManager) *ChipLedger {
+	return &ChipLedger{cm: cm}
+}
+
+// WithChipLedger wraps the player's chip manager in a ChipLedger. It should come
+// after any WithChipManager option.
+func WithChipLedger() Option {
+	return func(p *Player) {
+		p.chipManager = NewChipLedger(p.chipManager)
+	}
+}
+
+// ChipLedger returns the player's chip ledger, if their chip manager is one
+func (p *Player) ChipLedger() (*ChipLedger, bool) {
+	ledger, ok := p.chipManager.(*ChipLedger)
+	return ledger, ok && p.contextChips == nil
+}
+
+// GetChips returns the current chip count
+func (l *ChipLedger) GetChips() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cm.GetChips()
+}
+
+// SetChips sets the chip count to the specified amount
+func (l *ChipLedger) SetChips(amount int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	previous := l.cm.GetChips()
+	l.cm.SetChips(amount)
+	l.record(amount - previous)
+}
+
+// AddChips adds the specified amount to the chip count
+func (l *ChipLedger) AddChips(amount int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cm.AddChips(amount)
+	l.record(amount)
+}
+
+// DeductChips removes the specified amount from the chip count
+func (l *ChipLedger) DeductChips(amount int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.cm.DeductChips(amount); err != nil {
+		l.reason, l.roundID = "", 0
+		return err
+	}
+	l.record(-amount)
+	return nil
+}
+
+// HasEnoughChips returns true if there are enough chips for the specified amount
+func (l *ChipLedger) HasEnoughChips(amount int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cm.HasEnoughChips(amount)
+}
+
+// Transactions returns the recorded transactions selected by the filter, oldest first
+func (l *ChipLedger) Transactions(filter ChipTransactionFilter) []ChipTransaction {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var result []ChipTransaction
+	for _, tx := range l.transactions {
+		if filter.matches(tx) {
+			result = append(result, tx)
+		}
+	}
+	return result
+}
+
+// Total returns the net change in chips of the transactions selected by the filter
+func (l *ChipLedger) Total(filter ChipTransactionFilter) int {
+	total := 0
+	for _, tx := range l.Transactions(filter) {
+		total += tx.Amount
+	}
+	return total
+}
+
+// label sets the reason and round recorded with the next change
+func (l *ChipLedger) label(reason ChipReason, roundID int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reason, l.roundID = reason, roundID
+}
+
+// record adds a transaction for a change of the given amount, using and then
+// clearing the current label
+func (l *ChipLedger) record(amount int) {
+	if amount != 0 {
+		l.transactions = append(l.transactions, ChipTransaction{
+			Amount:    amount,
+			Balance:   l.cm.GetChips(),
+			Reason:    l.reason,
+			RoundID:   l.roundID,
+			Timestamp: time.Now(),
+		})
+	}
+	l.reason, l.roundID = "", 0
+}
+
+// labelChips labels the next change to the player's chips if they are recorded
+// by a ChipLedger
+func (p *Player) labelChips(reason ChipReason) {
+	ledger, ok := p.ChipLedger()
+	if !ok {
+		return
+	}
+	var roundID int64
+	if p.game != nil && p.game.roundInProgress() {
+		roundID = p.game.roundID
+	}
+	ledger.label(reason, roundID)
+}
@@ -0,0 +1,66 @@
+package blackjack
+
+import (
+	"context"
+	"log/slog"
+)
+
+// discardLogger is the logger of a game without one, which logs nothing
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// WithLogger sets the logger used by the game. Game flow is logged at the info
+// level (players joining and leaving, rounds starting and completing) and the
+// details of play at the debug level (bets, actions, dealer play, settlements).
+// Every message carries the game ID and round. By default nothing is logged.
+func WithLogger(logger *slog.Logger) GameOption {
+	return func(g *Game) {
+		g.SetLogger(logger)
+	}
+}
+
+// SetLogger sets the logger used by the game, or turns logging off if the logger
+// is nil
+func (bg *Game) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		bg.logger = nil
+		return
+	}
+	bg.logger = slog.New(&gameHandler{Handler: logger.Handler(), game: bg})
+}
+
+// log returns the game's logger, which attaches the game ID and round to each
+// message it logs
+func (bg *Game) log() *slog.Logger {
+	if bg.logger == nil {
+		return discardLogger
+	}
+	return bg.logger
+}
+
+// gameHandler attaches the game ID and current round to the messages that are
+// logged, so that the attributes are only built for messages at an enabled level
+type gameHandler struct {
+	slog.Handler
+	game *Game
+}
+
+// Handle logs the message with the game ID and round ahead of its attributes
+func (h *gameHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	record.AddAttrs(slog.String("game", h.game.id), slog.Int64("round", h.game.roundID))
+	r.Attrs(func(attr slog.Attr) bool {
+		record.AddAttrs(attr)
+		return true
+	})
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler that also attaches the given attributes
+func (h *gameHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &gameHandler{Handler: h.Handler.WithAttrs(attrs), game: h.game}
+}
+
+// WithGroup returns a handler that qualifies later attributes with the group
+func (h *gameHandler) WithGroup(name string) slog.Handler {
+	return &gameHandler{Handler: h.Handler.WithGroup(name), game: h.game}
+}
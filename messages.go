@@ -0,0 +1,145 @@
+package blackjack
+
+import (
+	"fmt"
+	"maps"
+	"sync/atomic"
+)
+
+// MessageKey identifies a user-facing message in a Catalog
+type MessageKey string
+
+const (
+	MsgResultPlayerWin       MessageKey = "result.player_win"       // MsgResultPlayerWin is the text for PlayerWin
+	MsgResultDealerWin       MessageKey = "result.dealer_win"       // MsgResultDealerWin is the text for DealerWin
+	MsgResultPush            MessageKey = "result.push"             // MsgResultPush is the text for Push
+	MsgResultPlayerBlackjack MessageKey = "result.player_blackjack" // MsgResultPlayerBlackjack is the text for PlayerBlackjack
+	MsgResultDealerBlackjack MessageKey = "result.dealer_blackjack" // MsgResultDealerBlackjack is the text for DealerBlackjack
+	MsgResultSurrendered     MessageKey = "result.surrendered"      // MsgResultSurrendered is the text for Surrendered
+	MsgResultPlayerCharlie   MessageKey = "result.player_charlie"   // MsgResultPlayerCharlie is the text for PlayerCharlie
+	MsgUnknown               MessageKey = "unknown"                 // MsgUnknown is the text for an unrecognized result or phase
+
+	MsgPhaseWaiting     MessageKey = "phase.waiting"      // MsgPhaseWaiting is the text for PhaseWaiting
+	MsgPhaseBetting     MessageKey = "phase.betting"      // MsgPhaseBetting is the text for PhaseBetting
+	MsgPhasePlayerTurns MessageKey = "phase.player_turns" // MsgPhasePlayerTurns is the text for PhasePlayerTurns
+	MsgPhaseDealerTurn  MessageKey = "phase.dealer_turn"  // MsgPhaseDealerTurn is the text for PhaseDealerTurn
+	MsgPhaseComplete    MessageKey = "phase.complete"     // MsgPhaseComplete is the text for PhaseComplete
+
+	MsgNoActions         MessageKey = "action.none"         // MsgNoActions is the summary of a hand with no actions
+	MsgActionDeal        MessageKey = "action.deal"         // MsgActionDeal is the summary of a deal
+	MsgActionDealCard    MessageKey = "action.deal_card"    // MsgActionDealCard is the summary of a deal, given the card
+	MsgActionHit         MessageKey = "action.hit"          // MsgActionHit is the summary of a hit
+	MsgActionHitCard     MessageKey = "action.hit_card"     // MsgActionHitCard is the summary of a hit, given the card
+	MsgActionStand       MessageKey = "action.stand"        // MsgActionStand is the summary of a stand
+	MsgActionDouble      MessageKey = "action.double"       // MsgActionDouble is the summary of a double down
+	MsgActionDoubleCard  MessageKey = "action.double_card"  // MsgActionDoubleCard is the summary of a double down, given the card
+	MsgActionSplit       MessageKey = "action.split"        // MsgActionSplit is the summary of a split
+	MsgActionSurrender   MessageKey = "action.surrender"    // MsgActionSurrender is the summary of a surrender
+	MsgActionBust        MessageKey = "action.bust"         // MsgActionBust is the summary of a bust
+	MsgActionSeparator   MessageKey = "action.separator"    // MsgActionSeparator separates actions in a summary
+	MsgActionWithDetails MessageKey = "action.with_details" // MsgActionWithDetails is an action summary, given the summary and its details
+	MsgActionWithValue   MessageKey = "action.with_value"   // MsgActionWithValue is an action summary, given the summary and the hand value after it
+	MsgHandEmpty         MessageKey = "hand.empty"          // MsgHandEmpty is the text for a hand with no cards
+	MsgHandValue         MessageKey = "hand.value"          // MsgHandValue is a hand, given its cards and value
+	MsgHandVisibleValue  MessageKey = "hand.visible_value"  // MsgHandVisibleValue is a dealer hand, given its visible cards and their value
+	MsgHandSplit         MessageKey = "hand.split"          // MsgHandSplit is appended to a hand that was split
+	MsgHandLabel         MessageKey = "hand.label"          // MsgHandLabel is the name of a hand, given its ID
+	MsgHandSplitFrom     MessageKey = "hand.split_from"     // MsgHandSplitFrom is the name of a split hand, given its ID and its parent's ID
+	MsgHandHidden        MessageKey = "hand.hidden"         // MsgHandHidden is shown in place of the dealer's hole card
+	MsgPlayerActive      MessageKey = "player.active"       // MsgPlayerActive is the status of an active player
+	MsgPlayerInactive    MessageKey = "player.inactive"     // MsgPlayerInactive is the status of an inactive player
+	MsgDealer            MessageKey = "dealer"              // MsgDealer is the dealer, given their hand
+
+	MsgSummaryPlayer      MessageKey = "summary.player"       // MsgSummaryPlayer is a player's outcome, given their name and result
+	MsgSummaryPlayerHands MessageKey = "summary.player_hands" // MsgSummaryPlayerHands heads the outcomes of a player's split hands, given their name
+	MsgSummaryHand        MessageKey = "summary.hand"         // MsgSummaryHand is the outcome of a split hand, given its label and result
+	MsgSummaryChips       MessageKey = "summary.chips"        // MsgSummaryChips is a player's balance after a round, given their chips
+)
+
+// Catalog maps message keys to the text shown to users. Messages that take
+// arguments are fmt format strings.
+type Catalog map[MessageKey]string
+
+// DefaultCatalog is the English catalog used for any message a custom catalog
+// does not define
+var DefaultCatalog = Catalog{
+	MsgResultPlayerWin:       "Player Wins",
+	MsgResultDealerWin:       "Dealer Wins",
+	MsgResultPush:            "Push (Tie)",
+	MsgResultPlayerBlackjack: "Player Blackjack!",
+	MsgResultDealerBlackjack: "Dealer Blackjack!",
+	MsgResultSurrendered:     "Surrendered",
+	MsgResultPlayerCharlie:   "Player Charlie!",
+	MsgUnknown:               "Unknown",
+
+	MsgPhaseWaiting:     "Waiting",
+	MsgPhaseBetting:     "Betting",
+	MsgPhasePlayerTurns: "Player Turns",
+	MsgPhaseDealerTurn:  "Dealer Turn",
+	MsgPhaseComplete:    "Complete",
+
+	MsgNoActions:         "No actions",
+	MsgActionDeal:        "dealt",
+	MsgActionDealCard:    "dealt %s",
+	MsgActionHit:         "hit",
+	MsgActionHitCard:     "hit %s",
+	MsgActionStand:       "stand",
+	MsgActionDouble:      "double",
+	MsgActionDoubleCard:  "double %s",
+	MsgActionSplit:       "split",
+	MsgActionSurrender:   "surrender",
+	MsgActionBust:        "bust",
+	MsgActionSeparator:   ", ",
+	MsgActionWithDetails: "%s (%s)",
+	MsgActionWithValue:   "%s → %d",
+	MsgHandEmpty:         "Empty hand",
+	MsgHandValue:         "[%s] (Value: %d)",
+	MsgHandVisibleValue:  "[%s] (Visible Value: %d)",
+	MsgHandSplit:         " (Split)",
+	MsgHandLabel:         "Hand %d",
+	MsgHandSplitFrom:     "Hand %d (split from Hand %d)",
+	MsgHandHidden:        "Hidden",
+	MsgPlayerActive:      "active",
+	MsgPlayerInactive:    "inactive",
+	MsgDealer:            "Dealer: %s",
+
+	MsgSummaryPlayer:      "%s: %s",
+	MsgSummaryPlayerHands: "%s:",
+	MsgSummaryHand:        "  %s: %s",
+	MsgSummaryChips:       "  Final Chips: %d",
+}
+
+// catalog is the catalog currently used for user-facing text
+var catalog atomic.Pointer[Catalog]
+
+// SetCatalog sets the catalog used for user-facing text, such as GameResult.String,
+// Phase.String, and Hand.ActionSummary. Messages missing from the catalog fall back
+// to DefaultCatalog. Passing nil restores the default catalog.
+func SetCatalog(c Catalog) {
+	if c == nil {
+		catalog.Store(nil)
+		return
+	}
+	merged := maps.Clone(DefaultCatalog)
+	maps.Copy(merged, c)
+	catalog.Store(&merged)
+}
+
+// Message returns the text for a message key from the current catalog, formatted
+// with the given arguments. The key itself is returned if no catalog defines it.
+func Message(key MessageKey, args ...any) string {
+	text, ok := "", false
+	if c := catalog.Load(); c != nil {
+		text, ok = (*c)[key]
+	}
+	if !ok {
+		text, ok = DefaultCatalog[key]
+	}
+	if !ok {
+		text = string(key)
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
@@ -0,0 +1,114 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const (
+	SaveFormatVersion = 3 // SaveFormatVersion is the version of the format written by Game.Save
+)
+
+// Migration upgrades a decoded save from one format version to the next by
+// modifying it in place
+type Migration func(save map[string]any) error
+
+var (
+	migrationsMu sync.RWMutex
+	migrations   = map[int]Migration{
+		// Saves written before the format was versioned have the same layout as version 1
+		0: func(map[string]any) error { return nil },
+		// Version 1 saves record game results as numbers, which still decode
+		1: func(map[string]any) error { return nil },
+		// Version 2 saves name the table rules by their Go field names
+		2: func(save map[string]any) error {
+			migrateRules(save)
+			return nil
+		},
+	}
+)
+
+// legacyRuleNames maps the names the table rules were saved under before Rules had
+// JSON tags to their current names
+var legacyRuleNames = map[string]string{
+	"DealerPeek":          "dealer_peek",
+	"MinBet":              "min_bet",
+	"MaxBet":              "max_bet",
+	"BetIncrement":        "bet_increment",
+	"MaxSpots":            "max_spots",
+	"NoSurrender":         "no_surrender",
+	"SurrenderAfterSplit": "surrender_after_split",
+	"MaxSplitHands":       "max_split_hands",
+	"DoubleMin":           "double_min",
+	"DoubleMax":           "double_max",
+	"NoDoubleAfterSplit":  "no_double_after_split",
+	"ResplitAces":         "resplit_aces",
+	"HitSplitAces":        "hit_split_aces",
+	"StandSoft17":         "stand_soft_17",
+	"BlackjackPayout":     "blackjack_payout",
+	"CharlieCards":        "charlie_cards",
+}
+
+// migrateRules renames the table rules of a decoded save or replay file from
+// their Go field names to their current names
+func migrateRules(save map[string]any) {
+	rules, ok := save["rules"].(map[string]any)
+	if !ok {
+		return
+	}
+	for old, name := range legacyRuleNames {
+		if value, ok := rules[old]; ok {
+			delete(rules, old)
+			rules[name] = value
+		}
+	}
+}
+
+// RegisterMigration registers the migration used to upgrade saves written in the
+// given format version to the following version. Registering a migration for a
+// version replaces any existing migration for it.
+func RegisterMigration(fromVersion int, migration Migration) {
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+	migrations[fromVersion] = migration
+}
+
+// migrateSave upgrades serialized game data to the current save format version
+func migrateSave(data []byte) ([]byte, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	if header.Version == SaveFormatVersion {
+		return data, nil
+	}
+	if header.Version > SaveFormatVersion {
+		return nil, fmt.Errorf("save format version %d is newer than the supported version %d", header.Version, SaveFormatVersion)
+	}
+
+	var save map[string]any
+	if err := json.Unmarshal(data, &save); err != nil {
+		return nil, err
+	}
+	if save == nil {
+		return nil, fmt.Errorf("save is not a JSON object")
+	}
+
+	migrationsMu.RLock()
+	defer migrationsMu.RUnlock()
+	for version := header.Version; version < SaveFormatVersion; version++ {
+		migration, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from save format version %d", version)
+		}
+		if err := migration(save); err != nil {
+			return nil, fmt.Errorf("failed to migrate save from version %d: %w", version, err)
+		}
+		save["version"] = version + 1
+	}
+
+	return json.Marshal(save)
+}
@@ -0,0 +1,540 @@
+package blackjack
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rbrabson/cards"
+)
+
+// Hand notation is a compact, human readable format for a single round, in the
+// spirit of chess PGN. A round is written as each seat followed by the dealer,
+// separated by " | ":
+//
+//	alice: 8♠8♥ SPLIT(3♦,J♠) H(5♣) S S | bob: T♣6♦ R | D: A♣ [T♦] H(7♥)
+//
+// Each seat starts with the player's name and their two initial cards, followed
+// by their moves in the order they were played:
+//
+//	H(c)        hit, receiving card c
+//	S           stand
+//	D(c)        double down, receiving card c
+//	SPLIT(a,b)  split, dealing a to the split hand and b to the new hand
+//	R           surrender
+//
+// Stands that the game makes automatically (after a double down or surrender,
+// on reaching 21, and on split aces) are not written. A player playing several
+// spots has one seat per spot. The dealer is always last, written as the upcard,
+// the hole card in brackets, and any hits. Cards are written as a rank (A, 2-9,
+// T, J, Q, K) followed by a suit (♠, ♥, ♦, ♣); the letters s, h, d, and c are
+// also accepted for suits when parsing.
+
+// Move is a single player move in hand notation
+type Move struct {
+	Action ActionType   // Action is the move made (hit, stand, double, split, or surrender)
+	Cards  []cards.Card // Cards are the cards dealt by the move
+}
+
+// SeatNotation is a single seat (one spot of one player) in hand notation
+type SeatNotation struct {
+	Name  string       // Name is the player's name
+	Cards []cards.Card // Cards are the player's two initial cards
+	Moves []Move       // Moves are the player's moves, in the order they were made
+}
+
+// DealerNotation is the dealer's hand in hand notation
+type DealerNotation struct {
+	Upcard   cards.Card   // Upcard is the dealer's face up card
+	HoleCard cards.Card   // HoleCard is the dealer's face down card
+	Hits     []cards.Card // Hits are the cards the dealer drew
+}
+
+// RoundNotation is a round in hand notation
+type RoundNotation struct {
+	Seats  []SeatNotation // Seats are the seats dealt into the round, in seat order
+	Dealer DealerNotation // Dealer is the dealer's hand
+}
+
+// noteStep is an action along with the index of the hand it was taken on
+type noteStep struct {
+	hand   int
+	action Action
+}
+
+// NotationFromRecord converts a completed round into hand notation
+func NotationFromRecord(record *RoundRecord) (*RoundNotation, error) {
+	if len(record.DealerCards) < 2 {
+		return nil, fmt.Errorf("round %d has no dealer cards", record.ID)
+	}
+	n := &RoundNotation{
+		Dealer: DealerNotation{
+			Upcard:   record.DealerCards[0],
+			HoleCard: record.DealerCards[1],
+			Hits:     record.DealerCards[2:],
+		},
+	}
+
+	for _, seat := range record.Seats {
+		if strings.ContainsAny(seat.Name, ":|") || strings.TrimSpace(seat.Name) != seat.Name || seat.Name == "" {
+			return nil, fmt.Errorf("player name %q cannot be written in hand notation", seat.Name)
+		}
+		for spot := range max(1, len(seat.Bets)) {
+			sn, err := seatNotation(seat, spot)
+			if err != nil {
+				return nil, err
+			}
+			n.Seats = append(n.Seats, sn)
+		}
+	}
+	return n, nil
+}
+
+// seatNotation converts the hands played on one of the player's spots into hand notation
+func seatNotation(seat SeatRecord, spot int) (SeatNotation, error) {
+	sn := SeatNotation{Name: seat.Name}
+
+	var steps []noteStep
+	for i, hand := range seat.Hands {
+		if hand.Spot != spot {
+			continue
+		}
+		for _, action := range hand.Actions {
+			steps = append(steps, noteStep{hand: i, action: action})
+		}
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].action.Timestamp.Before(steps[j].action.Timestamp)
+	})
+
+	// Track each hand's cards and whether the game has already stood it, so that
+	// automatic stands can be left out
+	held := make(map[int][]cards.Card)
+	closed := make(map[int]bool)
+	var split *Move
+	for _, step := range steps {
+		action := step.action
+		switch action.Type {
+		case ActionDeal:
+			if action.Card == nil {
+				continue
+			}
+			held[step.hand] = append(held[step.hand], *action.Card)
+			switch action.Details {
+			case "initial deal":
+				sn.Cards = append(sn.Cards, *action.Card)
+			case "split second card":
+				if split == nil {
+					return sn, fmt.Errorf("split card dealt to %s without a split", seat.Name)
+				}
+				split.Cards = append(split.Cards, *action.Card)
+				if len(split.Cards) == 2 {
+					sn.Moves = append(sn.Moves, *split)
+					split = nil
+				}
+				closed[step.hand] = isComplete(held[step.hand], true)
+			}
+		case ActionSplit:
+			if action.Details == "created from split" {
+				continue
+			}
+			held[step.hand] = held[step.hand][:1]
+			split = &Move{Action: ActionSplit}
+		case ActionHit:
+			held[step.hand] = append(held[step.hand], *action.Card)
+			sn.Moves = append(sn.Moves, Move{Action: ActionHit, Cards: []cards.Card{*action.Card}})
+			closed[step.hand] = cardsValue(held[step.hand]) == 21
+		case ActionDouble:
+			closed[step.hand] = true
+			if action.Card != nil {
+				held[step.hand] = append(held[step.hand], *action.Card)
+				sn.Moves = append(sn.Moves, Move{Action: ActionDouble, Cards: []cards.Card{*action.Card}})
+			}
+		case ActionSurrender:
+			closed[step.hand] = true
+			sn.Moves = append(sn.Moves, Move{Action: ActionSurrender})
+		case ActionStand:
+			if !closed[step.hand] {
+				sn.Moves = append(sn.Moves, Move{Action: ActionStand})
+			}
+			closed[step.hand] = true
+		}
+	}
+	if len(sn.Cards) != 2 {
+		return sn, fmt.Errorf("spot %d of %s was not dealt two cards", spot+1, seat.Name)
+	}
+	return sn, nil
+}
+
+// isComplete returns true if the game stands a hand with these cards automatically
+func isComplete(held []cards.Card, split bool) bool {
+	return cardsValue(held) == 21 || (split && len(held) == 2 && held[0].Rank == cards.Ace)
+}
+
+// FormatRound writes a completed round in hand notation
+func FormatRound(record *RoundRecord) (string, error) {
+	n, err := NotationFromRecord(record)
+	if err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// String returns the round in hand notation
+func (n *RoundNotation) String() string {
+	var sb strings.Builder
+	for _, seat := range n.Seats {
+		sb.WriteString(seat.Name)
+		sb.WriteString(": ")
+		for _, card := range seat.Cards {
+			sb.WriteString(CardNotation(card))
+		}
+		for _, move := range seat.Moves {
+			sb.WriteByte(' ')
+			sb.WriteString(move.String())
+		}
+		sb.WriteString(" | ")
+	}
+	sb.WriteString("D: ")
+	sb.WriteString(CardNotation(n.Dealer.Upcard))
+	sb.WriteString(" [")
+	sb.WriteString(CardNotation(n.Dealer.HoleCard))
+	sb.WriteString("]")
+	for _, card := range n.Dealer.Hits {
+		sb.WriteString(" H(")
+		sb.WriteString(CardNotation(card))
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// String returns the move in hand notation
+func (m Move) String() string {
+	cardList := make([]string, 0, len(m.Cards))
+	for _, card := range m.Cards {
+		cardList = append(cardList, CardNotation(card))
+	}
+	switch m.Action {
+	case ActionHit:
+		return "H(" + strings.Join(cardList, ",") + ")"
+	case ActionDouble:
+		return "D(" + strings.Join(cardList, ",") + ")"
+	case ActionSplit:
+		return "SPLIT(" + strings.Join(cardList, ",") + ")"
+	case ActionStand:
+		return "S"
+	case ActionSurrender:
+		return "R"
+	default:
+		return "?"
+	}
+}
+
+// notationRanks are the rank characters used in hand notation, indexed by rank
+var notationRanks = []string{"", "A", "2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "Q", "K"}
+
+// notationSuits maps suits to the symbols used in hand notation
+var notationSuits = map[cards.Suit]string{
+	cards.Spades:   "♠",
+	cards.Hearts:   "♥",
+	cards.Diamonds: "♦",
+	cards.Clubs:    "♣",
+}
+
+// CardNotation returns a card in hand notation, such as "T♦"
+func CardNotation(card cards.Card) string {
+	rank := "?"
+	if int(card.Rank) > 0 && int(card.Rank) < len(notationRanks) {
+		rank = notationRanks[card.Rank]
+	}
+	suit, ok := notationSuits[card.Suit]
+	if !ok {
+		suit = "?"
+	}
+	return rank + suit
+}
+
+// ParseCard parses a single card in hand notation
+func ParseCard(s string) (cards.Card, error) {
+	card, rest, err := parseCard(s)
+	if err != nil {
+		return card, err
+	}
+	if rest != "" {
+		return card, fmt.Errorf("unexpected %q after card", rest)
+	}
+	return card, nil
+}
+
+// parseCard parses the card at the start of s, returning the rest of s
+func parseCard(s string) (cards.Card, string, error) {
+	var card cards.Card
+	if s == "" {
+		return card, s, fmt.Errorf("missing card")
+	}
+	rank := strings.ToUpper(s[:1])
+	for i, r := range notationRanks {
+		if i > 0 && r == rank {
+			card.Rank = cards.Rank(i)
+		}
+	}
+	if card.Rank == 0 {
+		return card, s, fmt.Errorf("invalid rank in card %q", s)
+	}
+	s = s[1:]
+	for suit, symbol := range notationSuits {
+		if strings.HasPrefix(s, symbol) {
+			return cards.Card{Suit: suit, Rank: card.Rank}, s[len(symbol):], nil
+		}
+	}
+	if s != "" {
+		switch s[0] {
+		case 's', 'S':
+			card.Suit = cards.Spades
+		case 'h', 'H':
+			card.Suit = cards.Hearts
+		case 'd', 'D':
+			card.Suit = cards.Diamonds
+		case 'c', 'C':
+			card.Suit = cards.Clubs
+		}
+	}
+	if card.Suit == 0 {
+		return card, s, fmt.Errorf("invalid suit in card %q", notationRanks[card.Rank]+s)
+	}
+	return card, s[1:], nil
+}
+
+// parseCards parses a run of cards with nothing between them, such as "8♠8♥"
+func parseCards(s string) ([]cards.Card, error) {
+	var result []cards.Card
+	for s != "" {
+		card, rest, err := parseCard(s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, card)
+		s = rest
+	}
+	return result, nil
+}
+
+// ParseNotation parses a round written in hand notation
+func ParseNotation(s string) (*RoundNotation, error) {
+	sections := strings.Split(s, "|")
+	n := &RoundNotation{}
+	for i, section := range sections {
+		name, body, ok := strings.Cut(section, ":")
+		if !ok {
+			return nil, fmt.Errorf("section %d is missing a name", i+1)
+		}
+		name = strings.TrimSpace(name)
+		// Allow spaces after the commas of a split
+		fields := strings.Fields(strings.ReplaceAll(body, ", ", ","))
+		if i == len(sections)-1 {
+			if name != "D" {
+				return nil, fmt.Errorf("the last section must be the dealer's")
+			}
+			dealer, err := parseDealer(fields)
+			if err != nil {
+				return nil, fmt.Errorf("dealer: %w", err)
+			}
+			n.Dealer = dealer
+			continue
+		}
+		if name == "" {
+			return nil, fmt.Errorf("section %d is missing a name", i+1)
+		}
+		seat, err := parseSeat(name, fields)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		n.Seats = append(n.Seats, seat)
+	}
+	return n, nil
+}
+
+// parseSeat parses the fields of a player's seat
+func parseSeat(name string, fields []string) (SeatNotation, error) {
+	seat := SeatNotation{Name: name}
+	if len(fields) == 0 {
+		return seat, fmt.Errorf("missing initial cards")
+	}
+	initial, err := parseCards(fields[0])
+	if err != nil {
+		return seat, err
+	}
+	if len(initial) != 2 {
+		return seat, fmt.Errorf("expected two initial cards, got %d", len(initial))
+	}
+	seat.Cards = initial
+
+	for _, field := range fields[1:] {
+		move, err := parseMove(field)
+		if err != nil {
+			return seat, err
+		}
+		seat.Moves = append(seat.Moves, move)
+	}
+	return seat, nil
+}
+
+// parseMove parses a single move, such as "H(3♦)" or "S"
+func parseMove(field string) (Move, error) {
+	name, args, hasArgs := strings.Cut(field, "(")
+	var dealt []cards.Card
+	if hasArgs {
+		if !strings.HasSuffix(args, ")") {
+			return Move{}, fmt.Errorf("unterminated move %q", field)
+		}
+		for _, arg := range strings.Split(strings.TrimSuffix(args, ")"), ",") {
+			card, err := ParseCard(arg)
+			if err != nil {
+				return Move{}, fmt.Errorf("move %q: %w", field, err)
+			}
+			dealt = append(dealt, card)
+		}
+	}
+
+	var move Move
+	var want int
+	switch strings.ToUpper(name) {
+	case "H":
+		move, want = Move{Action: ActionHit}, 1
+	case "D":
+		move, want = Move{Action: ActionDouble}, 1
+	case "SPLIT":
+		move, want = Move{Action: ActionSplit}, 2
+	case "S":
+		move = Move{Action: ActionStand}
+	case "R":
+		move = Move{Action: ActionSurrender}
+	default:
+		return Move{}, fmt.Errorf("unknown move %q", field)
+	}
+	if len(dealt) != want {
+		return Move{}, fmt.Errorf("move %q must deal %d card(s)", field, want)
+	}
+	move.Cards = dealt
+	return move, nil
+}
+
+// parseDealer parses the fields of the dealer's hand
+func parseDealer(fields []string) (DealerNotation, error) {
+	var dealer DealerNotation
+	if len(fields) < 2 {
+		return dealer, fmt.Errorf("expected an upcard and a hole card")
+	}
+	upcard, err := ParseCard(fields[0])
+	if err != nil {
+		return dealer, err
+	}
+	hole := fields[1]
+	if !strings.HasPrefix(hole, "[") || !strings.HasSuffix(hole, "]") {
+		return dealer, fmt.Errorf("the hole card must be written in brackets")
+	}
+	holeCard, err := ParseCard(hole[1 : len(hole)-1])
+	if err != nil {
+		return dealer, err
+	}
+	dealer.Upcard, dealer.HoleCard = upcard, holeCard
+
+	for _, field := range fields[2:] {
+		move, err := parseMove(field)
+		if err != nil {
+			return dealer, err
+		}
+		if move.Action != ActionHit {
+			return dealer, fmt.Errorf("the dealer may only hit, got %q", field)
+		}
+		dealer.Hits = append(dealer.Hits, move.Cards...)
+	}
+	return dealer, nil
+}
+
+// Cards returns every card in the round in the order it was dealt from the shoe
+func (n *RoundNotation) Cards() []cards.Card {
+	var dealt []cards.Card
+	for _, seat := range n.Seats {
+		dealt = append(dealt, seat.Cards[0])
+	}
+	dealt = append(dealt, n.Dealer.Upcard)
+	for _, seat := range n.Seats {
+		dealt = append(dealt, seat.Cards[1])
+	}
+	dealt = append(dealt, n.Dealer.HoleCard)
+	for _, seat := range n.Seats {
+		for _, move := range seat.Moves {
+			dealt = append(dealt, move.Cards...)
+		}
+	}
+	return append(dealt, n.Dealer.Hits...)
+}
+
+// Play reconstructs the round by playing it in a new game dealt from a shoe stacked
+// with the round's cards. Each spot is bet the table minimum. The options configure
+// the game, such as its rules. An error is returned if the notation does not
+// describe a round that can be played under those rules.
+func (n *RoundNotation) Play(options ...GameOption) (*Game, error) {
+	game := New(1, options...)
+	game.SetShoe(NewShoeFromCards(n.Cards()))
+
+	bet := max(1, game.rules.MinBet)
+	if inc := game.rules.BetIncrement; inc > 0 && bet%inc != 0 {
+		bet += inc - bet%inc
+	}
+
+	// Seat each player with one spot per seat
+	var names []string
+	spots := make(map[string]int)
+	for i, seat := range n.Seats {
+		if spots[seat.Name] > 0 && n.Seats[i-1].Name != seat.Name {
+			return nil, fmt.Errorf("the seats of %s are not together", seat.Name)
+		}
+		if spots[seat.Name] == 0 {
+			names = append(names, seat.Name)
+		}
+		spots[seat.Name]++
+	}
+	for _, name := range names {
+		game.AddPlayer(name, WithChips(bet*spots[name]*16))
+	}
+
+	if err := game.StartNewRound(); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if err := game.SetPlayerSpots(name, spots[name]); err != nil {
+			return nil, err
+		}
+		for spot := range spots[name] {
+			if err := game.PlaceBet(name, spot, bet); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := game.DealInitialCards(); err != nil {
+		return nil, err
+	}
+	for _, seat := range n.Seats {
+		for _, move := range seat.Moves {
+			if _, err := game.Act(seat.Name, move.Action); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", seat.Name, move, err)
+			}
+		}
+	}
+	if _, err := game.SettleIfFinished(); err != nil {
+		return nil, err
+	}
+
+	if game.Phase() != PhaseComplete {
+		return nil, fmt.Errorf("the round is incomplete; a player has hands left to play")
+	}
+	if remaining := game.shoe.CardsRemaining(); remaining != 0 {
+		return nil, fmt.Errorf("the round ended with %d card(s) left undealt", remaining)
+	}
+	if got, want := game.dealer.Hand().Count(), 2+len(n.Dealer.Hits); got != want {
+		return nil, fmt.Errorf("the dealer drew %d card(s) but the notation has %d", got, want)
+	}
+	return game, nil
+}
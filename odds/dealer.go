@@ -0,0 +1,90 @@
+package odds
+
+import (
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/cards"
+)
+
+// DealerOdds are the chances of each way the dealer's hand can finish
+type DealerOdds struct {
+	Totals    [5]float64 // Totals are the probabilities the dealer stands on 17, 18, 19, 20, and 21, without a blackjack
+	Blackjack float64    // Blackjack is the probability the dealer has a natural blackjack
+	Bust      float64    // Bust is the probability the dealer busts
+}
+
+// dealerState is a point in the dealer's play, used to remember the odds already
+// worked out from it
+type dealerState struct {
+	total int         // total is the value of the dealer's hand
+	soft  bool        // soft is whether an ace is being counted as 11
+	up    bool        // up is whether the dealer holds only the up card
+	shoe  Composition // shoe is the cards left to be dealt
+}
+
+// Dealer returns the chances of each way the dealer's hand finishes when the
+// dealer shows the up card, drawing the hole card and every hit from the
+// composition. The composition should hold only the cards not yet seen, so the up
+// card must not be in it. The dealer hits soft 17 unless the rules say to stand.
+func Dealer(upCard cards.Card, shoe Composition, rules blackjack.Rules) DealerOdds {
+	total, soft := add(0, false, Value(upCard))
+	memo := make(map[dealerState]DealerOdds)
+	return dealerPlay(dealerState{total: total, soft: soft, up: true, shoe: shoe}, rules, memo)
+}
+
+// dealerPlay returns the chances of each way the dealer's hand finishes from the
+// state, drawing until the dealer stands or busts
+func dealerPlay(state dealerState, rules blackjack.Rules, memo map[dealerState]DealerOdds) DealerOdds {
+	var odds DealerOdds
+	switch {
+	case state.total > 21:
+		odds.Bust = 1
+		return odds
+	case state.total > 17, state.total == 17 && (!state.soft || rules.StandSoft17):
+		odds.Totals[state.total-17] = 1
+		return odds
+	}
+	if known, ok := memo[state]; ok {
+		return known
+	}
+
+	// If the shoe runs out, the hand can't be finished and no outcome is counted
+	cardsLeft := state.shoe.Total()
+	for value := 1; value <= 10; value++ {
+		if state.shoe[value] == 0 {
+			continue
+		}
+		chance := float64(state.shoe[value]) / float64(cardsLeft)
+		next := dealerState{shoe: state.shoe}
+		next.shoe[value]--
+		next.total, next.soft = add(state.total, state.soft, value)
+		if state.up && next.total == 21 {
+			odds.Blackjack += chance
+			continue
+		}
+		odds.add(dealerPlay(next, rules, memo), chance)
+	}
+	memo[state] = odds
+	return odds
+}
+
+// add adds the odds, weighted by the chance of reaching them, to these odds
+func (d *DealerOdds) add(other DealerOdds, chance float64) {
+	for i := range d.Totals {
+		d.Totals[i] += chance * other.Totals[i]
+	}
+	d.Blackjack += chance * other.Blackjack
+	d.Bust += chance * other.Bust
+}
+
+// NoBlackjack returns the odds given that the dealer doesn't have a blackjack, as
+// when the dealer has peeked at the hole card and play continues
+func (d DealerOdds) NoBlackjack() DealerOdds {
+	rest := 1 - d.Blackjack
+	if rest <= 0 {
+		return DealerOdds{}
+	}
+	var odds DealerOdds
+	odds.add(d, 1/rest)
+	odds.Blackjack = 0
+	return odds
+}
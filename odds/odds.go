@@ -0,0 +1,128 @@
+// Package odds calculates blackjack probabilities from the composition of the
+// cards left to be dealt: the chances of what a hit does to a hand, for display in
+// trainers and hints, and of how the dealer's hand finishes, for analyzing the
+// expected value of a play.
+package odds
+
+import (
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/cards"
+)
+
+// Composition is the number of cards of each value left to be dealt, indexed by
+// value with an ace as 1 and every ten-value card as 10. Index 0 is unused.
+type Composition [11]int
+
+// FullShoe returns the composition of a freshly shuffled shoe of numDecks decks
+func FullShoe(numDecks int) Composition {
+	var c Composition
+	for value := 1; value <= 9; value++ {
+		c[value] = 4 * numDecks
+	}
+	c[10] = 16 * numDecks
+	return c
+}
+
+// FromShoe returns the composition of the cards left in the shoe
+func FromShoe(shoe *blackjack.Shoe) Composition {
+	var c Composition
+	for rank, count := range shoe.RankCounts() {
+		c[Value(cards.Card{Rank: rank})] += count
+	}
+	return c
+}
+
+// Value returns the value of a card, counting an ace as 1
+func Value(card cards.Card) int {
+	switch card.Rank {
+	case cards.Jack, cards.Queen, cards.King:
+		return 10
+	default:
+		return int(card.Rank)
+	}
+}
+
+// Total returns the number of cards in the composition
+func (c Composition) Total() int {
+	total := 0
+	for _, count := range c[1:] {
+		total += count
+	}
+	return total
+}
+
+// Add returns the composition with the cards added, such as the dealer's hole card,
+// which is out of the shoe but not yet seen
+func (c Composition) Add(cards ...cards.Card) Composition {
+	for _, card := range cards {
+		c[Value(card)]++
+	}
+	return c
+}
+
+// Remove returns the composition with the cards taken out, such as the cards seen
+// on the table. A value is never reduced below zero.
+func (c Composition) Remove(cards ...cards.Card) Composition {
+	for _, card := range cards {
+		if value := Value(card); c[value] > 0 {
+			c[value]--
+		}
+	}
+	return c
+}
+
+// HitOdds are the chances of what the next card does to a hand
+type HitOdds struct {
+	Bust   float64    // Bust is the probability the next card busts the hand
+	Made   float64    // Made is the probability the next card leaves the hand on 17 through 21
+	Totals [5]float64 // Totals are the probabilities the next card leaves the hand on 17, 18, 19, 20, and 21
+}
+
+// Hit returns the chances of what the next card does to a hand of the total, which
+// is soft if an ace is being counted as 11, when the card is dealt from the
+// composition
+func Hit(total int, soft bool, shoe Composition) HitOdds {
+	var odds HitOdds
+	cardsLeft := shoe.Total()
+	if cardsLeft == 0 {
+		return odds
+	}
+	for value := 1; value <= 10; value++ {
+		if shoe[value] == 0 {
+			continue
+		}
+		chance := float64(shoe[value]) / float64(cardsLeft)
+		next, _ := add(total, soft, value)
+		switch {
+		case next > 21:
+			odds.Bust += chance
+		case next >= 17:
+			odds.Made += chance
+			odds.Totals[next-17] += chance
+		}
+	}
+	return odds
+}
+
+// HitHand returns the chances of what the next card does to the hand when the card
+// is dealt from the composition
+func HitHand(hand *blackjack.Hand, shoe Composition) HitOdds {
+	_, _, soft := hand.Values()
+	return Hit(hand.Value(), soft, shoe)
+}
+
+// add returns the total after a card of the value is added to a hand of the total,
+// counting an ace as 11 whenever it doesn't bust the hand, and whether the new
+// total is soft
+func add(total int, soft bool, value int) (int, bool) {
+	total += value
+	if value == 1 && total+10 <= 21 {
+		total += 10
+		soft = true
+	}
+	if total > 21 && soft {
+		total -= 10
+		soft = false
+	}
+	return total, soft
+}
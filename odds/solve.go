@@ -0,0 +1,207 @@
+package odds
+
+import (
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/cards"
+)
+
+// Solution is the expected value of each play of a hand, in bets won or lost, when
+// every later decision is also played perfectly for the cards left
+type Solution struct {
+	Best  blackjack.ActionType             // Best is the play with the highest expected value, not counting a split
+	EV    float64                          // EV is the expected value of the best play
+	Plays map[blackjack.ActionType]float64 // Plays are the expected values of each play the hand may make
+}
+
+// solver works out the expected values of a hand's plays against one dealer up
+// card, remembering each position it has already solved
+type solver struct {
+	rules     blackjack.Rules
+	upTotal   int  // upTotal is the value of the dealer's up card
+	upSoft    bool // upSoft is whether the up card is an ace
+	peeked    bool // peeked is whether the dealer is known not to have a blackjack
+	blackjack int  // blackjack is the value of the hole card that would give the dealer a blackjack
+
+	dealer map[dealerState]DealerOdds
+	stand  map[standState]float64
+	best   map[handState]float64
+}
+
+// standState is a player's total standing against the cards left
+type standState struct {
+	total int
+	shoe  Composition
+}
+
+// handState is a player's hand still to be played against the cards left
+type handState struct {
+	total int
+	soft  bool
+	count int // count is the number of cards in the hand
+	shoe  Composition
+}
+
+// Solve returns the expected value of each play of the player's hand against the
+// dealer's up card, with the cards dealt from the composition. The composition
+// should hold only the cards not yet seen, so neither the hand nor the up card may
+// be in it; the dealer's hole card is one of the cards in it. If the rules have the
+// dealer peek under an ace or ten-value up card, the dealer is known not to have a
+// blackjack, as the hand would otherwise have been settled already.
+//
+// Every possible draw is played out exactly, so Solve is intended for when few
+// cards are left, such as at the end of a single deck. Splitting is not considered,
+// so for a pair that may be split, Best is only the best of the other plays.
+func Solve(hand []cards.Card, upCard cards.Card, shoe Composition, rules blackjack.Rules) Solution {
+	s := &solver{
+		rules:  rules,
+		dealer: make(map[dealerState]DealerOdds),
+		stand:  make(map[standState]float64),
+		best:   make(map[handState]float64),
+	}
+	s.upTotal, s.upSoft = add(0, false, Value(upCard))
+	switch Value(upCard) {
+	case 1:
+		s.blackjack = 10
+	case 10:
+		s.blackjack = 1
+	}
+	s.peeked = rules.DealerPeek && s.blackjack != 0
+
+	total, soft := 0, false
+	for _, card := range hand {
+		total, soft = add(total, soft, Value(card))
+	}
+	solution := Solution{Plays: make(map[blackjack.ActionType]float64)}
+
+	if len(hand) == 2 && total == 21 {
+		dealerBlackjack := s.dealerOdds(shoe).Blackjack
+		solution.Plays[blackjack.ActionStand] = rules.BlackjackPays().Multiplier() * (1 - dealerBlackjack)
+	} else {
+		solution.Plays[blackjack.ActionStand] = s.final(total, len(hand), shoe)
+		if total < 21 {
+			solution.Plays[blackjack.ActionHit] = s.hit(handState{total: total, soft: soft, count: len(hand), shoe: shoe})
+		}
+		if len(hand) == 2 {
+			if (rules.DoubleMin == 0 || total >= rules.DoubleMin) && (rules.DoubleMax == 0 || total <= rules.DoubleMax) {
+				solution.Plays[blackjack.ActionDouble] = s.double(total, soft, shoe)
+			}
+			if !rules.NoSurrender {
+				// A surrender loses half the bet, even to a dealer blackjack
+				solution.Plays[blackjack.ActionSurrender] = -0.5
+			}
+		}
+	}
+
+	for _, action := range []blackjack.ActionType{blackjack.ActionStand, blackjack.ActionHit, blackjack.ActionDouble, blackjack.ActionSurrender} {
+		if ev, ok := solution.Plays[action]; ok && (solution.Best == "" || ev > solution.EV) {
+			solution.Best, solution.EV = action, ev
+		}
+	}
+	return solution
+}
+
+// dealerOdds returns the chances of how the dealer's hand finishes with the cards
+// left, given that the dealer doesn't have a blackjack if the dealer peeked
+func (s *solver) dealerOdds(shoe Composition) DealerOdds {
+	odds := dealerPlay(dealerState{total: s.upTotal, soft: s.upSoft, up: true, shoe: shoe}, s.rules, s.dealer)
+	if s.peeked {
+		return odds.NoBlackjack()
+	}
+	return odds
+}
+
+// draw returns the chance that the next card dealt from the composition is of the
+// value. When the dealer has peeked, the hole card is known not to be the card that
+// makes a blackjack, which changes the chances of the cards the player draws.
+func (s *solver) draw(shoe Composition, value int) float64 {
+	chance := float64(shoe[value]) / float64(shoe.Total())
+	if !s.peeked {
+		return chance
+	}
+	after := shoe
+	after[value]--
+	return chance * s.noBlackjack(after) / s.noBlackjack(shoe)
+}
+
+// noBlackjack returns the chance that a hole card drawn from the composition doesn't
+// give the dealer a blackjack
+func (s *solver) noBlackjack(shoe Composition) float64 {
+	total := shoe.Total()
+	if total == 0 {
+		return 1
+	}
+	return 1 - float64(shoe[s.blackjack])/float64(total)
+}
+
+// final returns the expected value of a hand that takes no more cards: a loss if it
+// busted, a win if it made a Charlie, or else a comparison with the dealer's hand
+func (s *solver) final(total, count int, shoe Composition) float64 {
+	switch {
+	case total > 21:
+		return -1
+	case s.rules.CharlieCards > 0 && count >= s.rules.CharlieCards:
+		dealerBlackjack := s.dealerOdds(shoe).Blackjack
+		return 1 - 2*dealerBlackjack
+	}
+
+	state := standState{total: total, shoe: shoe}
+	if ev, ok := s.stand[state]; ok {
+		return ev
+	}
+	odds := s.dealerOdds(shoe)
+	ev := odds.Bust - odds.Blackjack
+	for i, chance := range odds.Totals {
+		switch dealer := 17 + i; {
+		case total > dealer:
+			ev += chance
+		case total < dealer:
+			ev -= chance
+		}
+	}
+	s.stand[state] = ev
+	return ev
+}
+
+// hit returns the expected value of hitting the hand, then playing it perfectly
+func (s *solver) hit(state handState) float64 {
+	ev := 0.0
+	for value := 1; value <= 10; value++ {
+		if state.shoe[value] == 0 {
+			continue
+		}
+		next := handState{count: state.count + 1, shoe: state.shoe}
+		next.shoe[value]--
+		next.total, next.soft = add(state.total, state.soft, value)
+		ev += s.draw(state.shoe, value) * s.play(next)
+	}
+	return ev
+}
+
+// play returns the expected value of the hand played perfectly from here, standing
+// or hitting. A hand that busts, reaches 21, or makes a Charlie takes no more cards.
+func (s *solver) play(state handState) float64 {
+	if state.total >= 21 || (s.rules.CharlieCards > 0 && state.count >= s.rules.CharlieCards) || state.shoe.Total() == 0 {
+		return s.final(state.total, state.count, state.shoe)
+	}
+	if ev, ok := s.best[state]; ok {
+		return ev
+	}
+	ev := max(s.final(state.total, state.count, state.shoe), s.hit(state))
+	s.best[state] = ev
+	return ev
+}
+
+// double returns the expected value of doubling down on a two card hand
+func (s *solver) double(total int, soft bool, shoe Composition) float64 {
+	ev := 0.0
+	for value := 1; value <= 10; value++ {
+		if shoe[value] == 0 {
+			continue
+		}
+		after := shoe
+		after[value]--
+		next, _ := add(total, soft, value)
+		ev += s.draw(shoe, value) * 2 * s.final(next, 3, after)
+	}
+	return ev
+}
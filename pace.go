@@ -0,0 +1,39 @@
+package blackjack
+
+import "time"
+
+// PaceStats summarizes how quickly the rounds in a game's history were played
+type PaceStats struct {
+	Rounds               int                      `json:"rounds"`                 // Rounds is the number of completed rounds measured
+	TotalRoundTime       time.Duration            `json:"total_round_time"`       // TotalRoundTime is the time spent playing those rounds
+	AverageRoundDuration time.Duration            `json:"average_round_duration"` // AverageRoundDuration is the average time from round start to settlement
+	AverageDecisionTimes map[string]time.Duration `json:"average_decision_times"` // AverageDecisionTimes are each player's average decision time
+}
+
+// PaceStats returns the pace of play over the rounds in the game's history, so
+// operators can measure table speed and identify slow players
+func (bg *Game) PaceStats() PaceStats {
+	stats := PaceStats{AverageDecisionTimes: make(map[string]time.Duration)}
+	totals := make(map[string]time.Duration)
+	counts := make(map[string]int)
+
+	for _, record := range bg.history {
+		stats.Rounds++
+		stats.TotalRoundTime += record.Duration()
+		for _, seat := range record.Seats {
+			for _, d := range seat.DecisionTimes {
+				totals[seat.Name] += d
+				counts[seat.Name]++
+			}
+		}
+	}
+
+	if stats.Rounds > 0 {
+		stats.AverageRoundDuration = stats.TotalRoundTime / time.Duration(stats.Rounds)
+	}
+	for name, total := range totals {
+		stats.AverageDecisionTimes[name] = total / time.Duration(counts[name])
+	}
+
+	return stats
+}
@@ -0,0 +1,92 @@
+package blackjack
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// PayoutPolicy determines the net amount won or lost on a settled hand. The value
+// returned is added to the hand's bet when the hand is paid, so a positive amount
+// is a win, zero is a push, and a negative amount is a loss (e.g., -bet).
+type PayoutPolicy interface {
+	Payout(hand *Hand, eval Evaluation) int
+}
+
+// PayoutPolicyFunc adapts an ordinary function to the PayoutPolicy interface
+type PayoutPolicyFunc func(hand *Hand, eval Evaluation) int
+
+// Payout calls f(hand, eval)
+func (f PayoutPolicyFunc) Payout(hand *Hand, eval Evaluation) int {
+	return f(hand, eval)
+}
+
+// DefaultPayoutPolicy pays each hand its bet times the evaluation's multiplier,
+// truncating any fractional chips
+type DefaultPayoutPolicy struct{}
+
+// Payout returns the bet multiplied by the evaluation's payout multiplier
+func (DefaultPayoutPolicy) Payout(hand *Hand, eval Evaluation) int {
+	return exactPayout(hand.Bet(), eval.Multiplier, RoundDown)
+}
+
+// exactPayout returns the bet multiplied by the multiplier using exact rational
+// arithmetic, rounded to a whole chip with the rounding mode. Multiplying in
+// floating point would lose a chip on some bets, such as 62.999... for 45 at 7:5.
+func exactPayout(bet int, multiplier *big.Rat, rounding RoundingMode) int {
+	if multiplier == nil {
+		return 0
+	}
+	return rounding.Round(new(big.Rat).Mul(big.NewRat(int64(bet), 1), multiplier))
+}
+
+// PayoutRatio is the odds paid on a winning bet, such as 3:2 for a blackjack
+type PayoutRatio struct {
+	Win int `json:"win"` // Win is the amount paid for each Bet chips wagered
+	Bet int `json:"bet"` // Bet is the amount wagered to be paid Win chips
+}
+
+var (
+	ThreeToTwo = PayoutRatio{Win: 3, Bet: 2} // ThreeToTwo is the standard blackjack payout
+	SixToFive  = PayoutRatio{Win: 6, Bet: 5} // SixToFive is the reduced blackjack payout offered at some tables
+	TwoToOne   = PayoutRatio{Win: 2, Bet: 1} // TwoToOne is a promotional blackjack payout
+)
+
+// ParsePayoutRatio parses a ratio written as "win:bet", such as "6:5"
+func ParsePayoutRatio(s string) (PayoutRatio, error) {
+	win, bet, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return PayoutRatio{}, fmt.Errorf("payout ratio %q must be written as win:bet", s)
+	}
+	var r PayoutRatio
+	var err error
+	if r.Win, err = strconv.Atoi(win); err != nil || r.Win <= 0 {
+		return PayoutRatio{}, fmt.Errorf("invalid payout ratio %q", s)
+	}
+	if r.Bet, err = strconv.Atoi(bet); err != nil || r.Bet <= 0 {
+		return PayoutRatio{}, fmt.Errorf("invalid payout ratio %q", s)
+	}
+	return r, nil
+}
+
+// String returns the ratio written as "win:bet"
+func (r PayoutRatio) String() string {
+	return fmt.Sprintf("%d:%d", r.Win, r.Bet)
+}
+
+// Rat returns the amount paid per chip wagered as an exact fraction
+func (r PayoutRatio) Rat() *big.Rat {
+	if r.Bet == 0 {
+		return new(big.Rat)
+	}
+	return big.NewRat(int64(r.Win), int64(r.Bet))
+}
+
+// Multiplier returns the amount paid per chip wagered
+func (r PayoutRatio) Multiplier() float64 {
+	if r.Bet == 0 {
+		return 0
+	}
+	return float64(r.Win) / float64(r.Bet)
+}
@@ -0,0 +1,30 @@
+package blackjack
+
+// Phase represents the stage of the current round
+type Phase int
+
+const (
+	PhaseWaiting     Phase = iota // PhaseWaiting means no round has been started
+	PhaseBetting                  // PhaseBetting means players are placing their bets
+	PhasePlayerTurns              // PhasePlayerTurns means the initial cards are dealt and players are acting on their hands
+	PhaseDealerTurn               // PhaseDealerTurn means the dealer is playing out their hand
+	PhaseComplete                 // PhaseComplete means the round has been settled
+)
+
+// String returns a string representation of the phase
+func (p Phase) String() string {
+	switch p {
+	case PhaseWaiting:
+		return Message(MsgPhaseWaiting)
+	case PhaseBetting:
+		return Message(MsgPhaseBetting)
+	case PhasePlayerTurns:
+		return Message(MsgPhasePlayerTurns)
+	case PhaseDealerTurn:
+		return Message(MsgPhaseDealerTurn)
+	case PhaseComplete:
+		return Message(MsgPhaseComplete)
+	default:
+		return Message(MsgUnknown)
+	}
+}
@@ -1,8 +1,12 @@
 package blackjack
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 )
 
 // Player represents a blackjack player
@@ -10,8 +14,19 @@ type Player struct {
 	name           string
 	hands          []*Hand
 	chipManager    ChipManager
+	contextChips   ContextChipManager // contextChips is used in place of chipManager, if set
+	chipTimeout    time.Duration      // chipTimeout is how long a chip operation may take
+	startingChips  int
+	spots          int // spots is the number of initial hands the player plays each round
 	active         bool
+	waiting        bool // waiting is whether the player joined mid-round and is waiting for the next round
 	currentHandIdx int
+	game           *Game             // game is the game the player is seated in (nil if not seated)
+	lastAction     time.Time         // lastAction is when the player last acted on a hand in the game
+	lastBonus      time.Time         // lastBonus is when the player last claimed a bonus
+	stats          PlayerStats       // stats are the player's results in the rounds they have played
+	metadata       map[string]string // metadata is arbitrary information about the player kept for the integrating application
+	seat           Seat              // seat makes the player's decisions when the game is played with PlayRound
 }
 
 // NewPlayer creates a new player with the given name, initial chips, and optional settings
@@ -19,13 +34,15 @@ func NewPlayer(name string, options ...Option) *Player {
 	player := &Player{
 		name:           name,
 		chipManager:    NewDefaultChipManager(0),
+		spots:          1,
 		active:         true,
 		currentHandIdx: 0,
 	}
 	for _, option := range options {
 		option(player)
 	}
-	player.hands = []*Hand{NewHand(player)}
+	player.startingChips = player.Chips()
+	player.hands = []*Hand{newSpotHand(player, 0)}
 	return player
 }
 
@@ -44,10 +61,20 @@ func WithChipManager(cm ChipManager) Option {
 	}
 }
 
+// SetChipManager replaces the player's chip manager
+func (p *Player) SetChipManager(cm ChipManager) {
+	p.chipManager = cm
+	p.contextChips = nil
+}
+
 // WithAllowedMentions sets the allowed mentions for the message.
 func WithChips(chips int) Option {
 	return func(p *Player) {
-		p.chipManager.SetChips(chips)
+		ctx, cancel := p.chipContext(context.Background())
+		defer cancel()
+		if err := p.wallet().SetChips(ctx, chips); err != nil {
+			p.chipError("failed to set chips", err, "amount", chips)
+		}
 	}
 }
 
@@ -72,12 +99,69 @@ func (p *Player) NextHand() bool {
 
 // Chips returns the player's current chip count
 func (p *Player) Chips() int {
-	return p.chipManager.GetChips()
+	chips, err := p.ChipsContext(context.Background())
+	if err != nil {
+		p.chipError("failed to get chips", err)
+	}
+	return chips
+}
+
+// WithMetadata sets a metadata value for the player, such as an avatar URL or an
+// external user ID.
+func WithMetadata(key string, value string) Option {
+	return func(p *Player) {
+		p.SetMetadata(key, value)
+	}
+}
+
+// Metadata returns the player's metadata value for the key
+func (p *Player) Metadata(key string) (string, bool) {
+	value, ok := p.metadata[key]
+	return value, ok
+}
+
+// SetMetadata sets the player's metadata value for the key. Metadata is kept
+// across rounds and saved with the game.
+func (p *Player) SetMetadata(key string, value string) {
+	if p.metadata == nil {
+		p.metadata = make(map[string]string)
+	}
+	p.metadata[key] = value
+}
+
+// DeleteMetadata removes the player's metadata value for the key
+func (p *Player) DeleteMetadata(key string) {
+	delete(p.metadata, key)
+}
+
+// AllMetadata returns a copy of all of the player's metadata
+func (p *Player) AllMetadata() map[string]string {
+	return maps.Clone(p.metadata)
+}
+
+// StartingChips returns the chip count the player joined the game with
+func (p *Player) StartingChips() int {
+	return p.startingChips
+}
+
+// ResetChips restores the player's chips to the amount they joined the game with
+func (p *Player) ResetChips() {
+	previous := p.Chips()
+	ctx, cancel := p.chipContext(context.Background())
+	defer cancel()
+	p.labelChips(ChipReasonReset)
+	if err := p.wallet().SetChips(ctx, p.startingChips); err != nil {
+		p.chipError("failed to reset chips", err, "amount", p.startingChips)
+		return
+	}
+	p.chipsChanged(p.startingChips-previous, ChipReasonReset)
 }
 
 // AddChips adds chips to the player's account
 func (p *Player) AddChips(amount int) {
-	p.chipManager.AddChips(amount)
+	if err := p.creditChips(context.Background(), amount, ChipReasonRebuy); err != nil {
+		p.chipError("failed to add chips", err, "amount", amount)
+	}
 }
 
 // IsActive returns whether the player is still active in the game
@@ -85,6 +169,12 @@ func (p *Player) IsActive() bool {
 	return p.active
 }
 
+// IsWaiting returns true if the player joined while a round was in progress and
+// will be dealt in at the start of the next round
+func (p *Player) IsWaiting() bool {
+	return p.waiting
+}
+
 // SetActive sets the player's active status
 func (p *Player) SetActive(active bool) {
 	p.active = active
@@ -92,22 +182,87 @@ func (p *Player) SetActive(active bool) {
 
 // ClearHands clears all of the player's hands for a new round
 func (p *Player) ClearHands() {
-	// Reset to a single hand
-	p.hands = []*Hand{NewHand(p)}
+	// Reset to a single hand for each spot
+	p.hands = make([]*Hand, 0, p.spots)
+	for spot := 0; spot < p.spots; spot++ {
+		p.hands = append(p.hands, newSpotHand(p, spot))
+	}
+	p.currentHandIdx = 0
+}
+
+// Spots returns the number of spots (initial hands) the player plays each round
+func (p *Player) Spots() int {
+	return p.spots
+}
+
+// setSpots changes the number of spots the player plays. Spots are added or removed
+// from the current round's hands immediately, keeping the bets on the spots that
+// remain, but a spot with an unsettled bet may not be removed.
+func (p *Player) setSpots(spots int) error {
+	for _, hand := range p.hands {
+		if hand.spot >= spots && hand.Bet() > 0 && !hand.IsSettled() {
+			return fmt.Errorf("spot %d already has a bet", hand.spot+1)
+		}
+	}
+	p.spots = spots
+	hands := make([]*Hand, 0, spots)
+	for spot := range spots {
+		if i := slices.IndexFunc(p.hands, func(h *Hand) bool { return h.spot == spot }); i >= 0 {
+			hands = append(hands, p.hands[i])
+		} else {
+			hands = append(hands, newSpotHand(p, spot))
+		}
+	}
+	p.hands = hands
 	p.currentHandIdx = 0
+	return nil
+}
+
+// removeUnbetSpots removes the hands for any extra spots that do not have a bet,
+// always keeping at least one hand
+func (p *Player) removeUnbetSpots() {
+	if len(p.hands) <= 1 {
+		return
+	}
+	hands := make([]*Hand, 0, len(p.hands))
+	for _, hand := range p.hands {
+		if hand.Bet() > 0 {
+			hands = append(hands, hand)
+		}
+	}
+	if len(hands) == 0 {
+		hands = p.hands[:1]
+	}
+	for i, hand := range hands {
+		hand.id = i + 1
+	}
+	p.hands = hands
+	p.currentHandIdx = 0
+}
+
+// spotHandCount returns the number of hands the player has for the given spot,
+// including any hands split from it
+func (p *Player) spotHandCount(spot int) int {
+	count := 0
+	for _, hand := range p.hands {
+		if hand.spot == spot {
+			count++
+		}
+	}
+	return count
 }
 
 // String returns a string representation of the player
 func (p *Player) String() string {
-	status := "active"
+	status := Message(MsgPlayerActive)
 	if !p.active {
-		status = "inactive"
+		status = Message(MsgPlayerInactive)
 	}
 
 	if len(p.hands) == 1 {
 		// Single hand
 		return fmt.Sprintf("%s (Chips: %d, Bet: %d, %s): %s",
-			p.name, p.chipManager.GetChips(), p.hands[0].Bet(), status, p.hands[0].String())
+			p.name, p.Chips(), p.hands[0].Bet(), status, p.hands[0].String())
 	} else {
 		// Multiple hands (splits) - show total bet across all hands
 		totalBet := 0
@@ -123,7 +278,7 @@ func (p *Player) String() string {
 			handStrings[i] = fmt.Sprintf("Hand %d (Bet: %d): %s%s", i+1, hand.Bet(), hand.String(), current)
 		}
 		return fmt.Sprintf("%s (Chips: %d, Total Bet: %d, %s):\n  %s",
-			p.name, p.chipManager.GetChips(), totalBet, status, strings.Join(handStrings, "\n  "))
+			p.name, p.Chips(), totalBet, status, strings.Join(handStrings, "\n  "))
 	}
 }
 
@@ -151,12 +306,19 @@ func (p *Player) HasActiveHands() bool {
 	return false
 }
 
-// MoveToNextActiveHand moves to the next active hand, returns true if successful
+// MoveToNextActiveHand moves to the next active hand, returns true if successful.
+// Hands are played spot by spot, so hands split from a spot are played before
+// moving on to the next spot.
 func (p *Player) MoveToNextActiveHand() bool {
-	for idx, hand := range p.hands {
-		if !(hand.IsBusted() || hand.IsBlackjack() || hand.IsStood() || hand.IsSurrendered()) {
-			p.currentHandIdx = idx
-			return true
+	for spot := 0; spot < p.spots; spot++ {
+		for idx, hand := range p.hands {
+			if hand.spot != spot {
+				continue
+			}
+			if !(hand.IsBusted() || hand.IsBlackjack() || hand.IsStood() || hand.IsSurrendered()) {
+				p.currentHandIdx = idx
+				return true
+			}
 		}
 	}
 	return false
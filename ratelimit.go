@@ -0,0 +1,44 @@
+package blackjack
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRateLimited is returned when a player acts more often than the game allows
+var ErrRateLimited = errors.New("action rate limit exceeded")
+
+// WithActionRateLimit limits each player to one action per interval. Actions taken
+// too quickly fail with ErrRateLimited. Only actions that succeed count toward the
+// limit. An interval of zero disables the limit.
+func WithActionRateLimit(interval time.Duration) GameOption {
+	return func(g *Game) {
+		g.actionInterval = interval
+	}
+}
+
+// SetActionRateLimit changes the minimum interval between a player's actions
+func (bg *Game) SetActionRateLimit(interval time.Duration) {
+	bg.actionInterval = interval
+}
+
+// checkRateLimit returns an error if the player acted within the game's action interval
+func (bg *Game) checkRateLimit(player *Player) error {
+	if bg.actionInterval <= 0 {
+		return nil
+	}
+	if since := time.Since(player.lastAction); since < bg.actionInterval {
+		return fmt.Errorf("player %s must wait %s before acting again: %w",
+			player.Name(), (bg.actionInterval - since).Round(time.Millisecond), ErrRateLimited)
+	}
+	return nil
+}
+
+// recordRateLimit records the time of the player's action once it has been taken,
+// so that an action that fails does not count against the player's rate limit
+func (bg *Game) recordRateLimit(player *Player) {
+	if bg.actionInterval > 0 {
+		player.lastAction = time.Now()
+	}
+}
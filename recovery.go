@@ -0,0 +1,29 @@
+package blackjack
+
+// WithSnapshots saves the game to the store after every phase transition, so that
+// a round interrupted by a crash can be resumed with Recover.
+func WithSnapshots(store GameStore) GameOption {
+	return func(g *Game) {
+		g.snapshots = store
+	}
+}
+
+// snapshot saves the game to the snapshot store, if one is configured. A failed
+// snapshot is logged rather than interrupting play.
+func (bg *Game) snapshot() {
+	if bg.snapshots == nil {
+		return
+	}
+	if err := bg.SaveTo(bg.snapshots); err != nil {
+		bg.log().Error("failed to snapshot game", "phase", bg.phase, "error", err)
+	}
+}
+
+// Recover resumes a game from its most recent snapshot in the store, continuing
+// any round that was in progress when the game was interrupted. The recovered game
+// keeps saving snapshots to the store. Options are applied to the recovered game
+// as with Load.
+func Recover(store GameStore, gameID string, options ...GameOption) (*Game, error) {
+	options = append([]GameOption{WithSnapshots(store)}, options...)
+	return LoadFrom(store, gameID, options...)
+}
@@ -0,0 +1,114 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+var (
+	fontsOnce   sync.Once
+	regularFont *opentype.Font
+	boldFont    *opentype.Font
+	fontsErr    error
+)
+
+// loadFonts parses the Go fonts used to draw text
+func loadFonts() error {
+	fontsOnce.Do(func() {
+		if regularFont, fontsErr = opentype.Parse(goregular.TTF); fontsErr != nil {
+			return
+		}
+		boldFont, fontsErr = opentype.Parse(gobold.TTF)
+	})
+	return fontsErr
+}
+
+// writePNG rasterizes the scene at the given scale and writes it as a PNG image
+func (s *scene) writePNG(w io.Writer, scale float64) error {
+	if err := loadFonts(); err != nil {
+		return fmt.Errorf("failed to load fonts: %w", err)
+	}
+	width := int(math.Ceil(s.width * scale))
+	height := int(math.Ceil(s.height * scale))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	faces := make(map[[2]float64]font.Face)
+	defer func() {
+		for _, face := range faces {
+			face.Close()
+		}
+	}()
+
+	for _, it := range s.items {
+		switch {
+		case it.path != nil:
+			rasterizePath(img, it.path, scale)
+		case it.text != nil:
+			t := it.text
+			key := [2]float64{t.size * scale, 0}
+			if t.bold {
+				key[1] = 1
+			}
+			face, ok := faces[key]
+			if !ok {
+				f := regularFont
+				if t.bold {
+					f = boldFont
+				}
+				var err error
+				face, err = opentype.NewFace(f, &opentype.FaceOptions{Size: key[0], DPI: 72, Hinting: font.HintingFull})
+				if err != nil {
+					return fmt.Errorf("failed to create font face: %w", err)
+				}
+				faces[key] = face
+			}
+			d := &font.Drawer{Dst: img, Src: image.NewUniform(t.fill), Face: face}
+			x := t.x * scale
+			if t.center {
+				x -= float64(d.MeasureString(t.s)) / 64 / 2
+			}
+			d.Dot = fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(t.y * scale * 64)}
+			d.DrawString(t.s)
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// rasterizePath fills the path onto the image
+func rasterizePath(img *image.RGBA, p *path, scale float64) {
+	b := img.Bounds()
+	z := vector.NewRasterizer(b.Dx(), b.Dy())
+	z.DrawOp = draw.Over
+	pt := func(v []float64, i int) (float32, float32) {
+		return float32(v[i] * scale), float32(v[i+1] * scale)
+	}
+	for _, op := range p.ops {
+		switch op.op {
+		case 'M':
+			z.MoveTo(pt(op.pts, 0))
+		case 'L':
+			z.LineTo(pt(op.pts, 0))
+		case 'C':
+			x1, y1 := pt(op.pts, 0)
+			x2, y2 := pt(op.pts, 2)
+			x, y := pt(op.pts, 4)
+			z.CubeTo(x1, y1, x2, y2, x, y)
+		case 'Z':
+			z.ClosePath()
+		}
+	}
+	z.Draw(img, b, image.NewUniform(color.RGBA(p.fill)), image.Point{})
+}
@@ -0,0 +1,251 @@
+// Package render draws blackjack hands and tables as SVG or PNG images, for use
+// in bots, web pages, and anywhere else a text rendering of the game won't do.
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strconv"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/cards"
+)
+
+const (
+	CardWidth  = 70  // CardWidth is the width of a card, in SVG units or unscaled pixels
+	CardHeight = 100 // CardHeight is the height of a card, in SVG units or unscaled pixels
+
+	cardRadius  = 6  // cardRadius is the radius of a card's corners
+	cardOverlap = 24 // cardOverlap is the horizontal distance between cards in a hand
+	margin      = 16 // margin is the space around the edges of an image
+	labelHeight = 20 // labelHeight is the height of a line of text
+	handGap     = 24 // handGap is the horizontal space between hands
+	rowGap      = 16 // rowGap is the vertical space between the dealer and each player
+)
+
+var (
+	white     = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	black     = color.RGBA{0x11, 0x11, 0x11, 0xff}
+	red       = color.RGBA{0xc6, 0x28, 0x28, 0xff}
+	gray      = color.RGBA{0x99, 0x99, 0x99, 0xff}
+	felt      = color.RGBA{0x0b, 0x6e, 0x3a, 0xff}
+	cardBack  = color.RGBA{0x1e, 0x3a, 0x8a, 0xff}
+	backTrim  = color.RGBA{0x3b, 0x5b, 0xc4, 0xff}
+	highlight = color.RGBA{0xff, 0xd5, 0x4f, 0xff}
+)
+
+// Option configures how an image is rendered
+type Option func(*options)
+
+// options are the settings used when rendering an image
+type options struct {
+	scale float64
+}
+
+// WithScale sets the scale at which PNG images are rasterized. The default scale
+// of 1 draws a card 70 pixels wide. SVG images are unaffected, since they scale freely.
+func WithScale(scale float64) Option {
+	return func(o *options) {
+		if scale > 0 {
+			o.scale = scale
+		}
+	}
+}
+
+// newOptions returns the rendering settings with the options applied
+func newOptions(opts []Option) options {
+	o := options{scale: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// HandSVG writes a player's hand as an SVG image
+func HandSVG(w io.Writer, hand blackjack.HandState) error {
+	return handScene(hand).writeSVG(w)
+}
+
+// HandPNG writes a player's hand as a PNG image
+func HandPNG(w io.Writer, hand blackjack.HandState, opts ...Option) error {
+	o := newOptions(opts)
+	return handScene(hand).writePNG(w, o.scale)
+}
+
+// TableSVG writes the state of a table, with the dealer's hand above each player's
+// hands, as an SVG image
+func TableSVG(w io.Writer, state blackjack.GameState) error {
+	return tableScene(state).writeSVG(w)
+}
+
+// TablePNG writes the state of a table as a PNG image
+func TablePNG(w io.Writer, state blackjack.GameState, opts ...Option) error {
+	o := newOptions(opts)
+	return tableScene(state).writePNG(w, o.scale)
+}
+
+// handScene lays out a single hand on the felt
+func handScene(hand blackjack.HandState) *scene {
+	s := &scene{
+		width:  2*margin + max(handWidth(len(hand.Cards)), 120),
+		height: 2*margin + CardHeight + labelHeight,
+	}
+	s.roundRect(0, 0, s.width, s.height, 0, felt)
+	drawHand(s, hand, margin, margin, false)
+	return s
+}
+
+// tableScene lays out the dealer and each player's hands on the felt
+func tableScene(state blackjack.GameState) *scene {
+	rowHeight := float64(labelHeight + CardHeight + labelHeight)
+
+	width := 2*margin + max(handWidth(len(state.Dealer.Cards)), 240)
+	for _, player := range state.Players {
+		width = max(width, 2*margin+playerWidth(player))
+	}
+	s := &scene{
+		width:  width,
+		height: 2*margin + float64(len(state.Players)+1)*rowHeight + float64(len(state.Players))*rowGap,
+	}
+	s.roundRect(0, 0, s.width, s.height, 0, felt)
+
+	y := float64(margin)
+	dealerLabel := "Dealer"
+	if len(state.Dealer.Cards) > 0 {
+		dealerLabel = fmt.Sprintf("Dealer (%d)", state.Dealer.Value)
+	}
+	s.fillText(text{x: margin, y: y + 14, size: 14, bold: true, fill: white, s: dealerLabel})
+	y += labelHeight
+	for i, card := range state.Dealer.Cards {
+		x := margin + float64(i*cardOverlap)
+		if card == nil {
+			drawCardBack(s, x, y)
+		} else {
+			drawCard(s, *card, x, y)
+		}
+	}
+	y += CardHeight + labelHeight + rowGap
+
+	acting := state.Phase == blackjack.PhasePlayerTurns
+	for _, player := range state.Players {
+		label := fmt.Sprintf("%s — %d chips", player.Name, player.Chips)
+		if player.Waiting {
+			label += " (waiting)"
+		}
+		s.fillText(text{x: margin, y: y + 14, size: 14, bold: true, fill: white, s: label})
+		x := float64(margin)
+		for i, hand := range player.Hands {
+			// Players act in seat order, so the first active hand is the one being played
+			current := acting && player.Active && i == player.CurrentHand && hand.Active
+			if current {
+				acting = false
+			}
+			drawHand(s, hand, x, y+labelHeight, current)
+			x += max(handWidth(len(hand.Cards)), 120) + handGap
+		}
+		y += rowHeight + rowGap
+	}
+
+	return s
+}
+
+// handWidth returns the width of a hand with the given number of cards
+func handWidth(n int) float64 {
+	if n == 0 {
+		return CardWidth
+	}
+	return float64(CardWidth + (n-1)*cardOverlap)
+}
+
+// playerWidth returns the width of all of a player's hands
+func playerWidth(player blackjack.PlayerState) float64 {
+	width := 0.0
+	for i, hand := range player.Hands {
+		if i > 0 {
+			width += handGap
+		}
+		width += max(handWidth(len(hand.Cards)), 120)
+	}
+	return width
+}
+
+// drawHand draws a hand's cards at (x, y) with a label beneath them. The hand
+// being played is outlined.
+func drawHand(s *scene, hand blackjack.HandState, x, y float64, current bool) {
+	if current {
+		s.roundRect(x-3, y-3, handWidth(len(hand.Cards))+6, CardHeight+6, cardRadius+3, highlight)
+	}
+	if len(hand.Cards) == 0 {
+		s.roundRect(x, y, CardWidth, CardHeight, cardRadius, color.RGBA{0xff, 0xff, 0xff, 0x30})
+	}
+	for i, card := range hand.Cards {
+		drawCard(s, card, x+float64(i*cardOverlap), y)
+	}
+	s.fillText(text{x: x, y: y + CardHeight + 15, size: 12, fill: white, s: handLabel(hand)})
+}
+
+// handLabel returns the text shown beneath a hand: its bet, value, and status
+func handLabel(hand blackjack.HandState) string {
+	label := "Bet " + strconv.Itoa(hand.Bet)
+	if len(hand.Cards) > 0 {
+		value := strconv.Itoa(hand.Value)
+		if hand.Soft {
+			value = "soft " + value
+		}
+		label += " · " + value
+	}
+	switch {
+	case hand.Blackjack:
+		label += " · Blackjack"
+	case hand.Busted:
+		label += " · Bust"
+	case hand.Surrendered:
+		label += " · Surrendered"
+	}
+	switch {
+	case hand.Winnings > 0:
+		label += " · +" + strconv.Itoa(hand.Winnings)
+	case hand.Winnings < 0:
+		label += " · " + strconv.Itoa(hand.Winnings)
+	}
+	return label
+}
+
+// drawCard draws a face up card with its top left corner at (x, y)
+func drawCard(s *scene, card cards.Card, x, y float64) {
+	fill := black
+	if card.Suit == cards.Hearts || card.Suit == cards.Diamonds {
+		fill = red
+	}
+	s.roundRect(x, y, CardWidth, CardHeight, cardRadius, gray)
+	s.roundRect(x+1, y+1, CardWidth-2, CardHeight-2, cardRadius-1, white)
+	s.fillText(text{x: x + 12, y: y + 20, size: 16, bold: true, center: true, fill: fill, s: rankLabel(card.Rank)})
+	s.fillPath(suitPath(card.Suit, x+5, y+25, 14, fill))
+	s.fillPath(suitPath(card.Suit, x+CardWidth/2-14, y+CardHeight/2-10, 36, fill))
+}
+
+// drawCardBack draws a face down card with its top left corner at (x, y)
+func drawCardBack(s *scene, x, y float64) {
+	s.roundRect(x, y, CardWidth, CardHeight, cardRadius, gray)
+	s.roundRect(x+1, y+1, CardWidth-2, CardHeight-2, cardRadius-1, white)
+	s.roundRect(x+5, y+5, CardWidth-10, CardHeight-10, cardRadius-2, cardBack)
+	s.roundRect(x+12, y+12, CardWidth-24, CardHeight-24, cardRadius-3, backTrim)
+	s.roundRect(x+15, y+15, CardWidth-30, CardHeight-30, cardRadius-4, cardBack)
+}
+
+// rankLabel returns the text shown in the corner of a card for its rank
+func rankLabel(rank cards.Rank) string {
+	switch rank {
+	case cards.Ace:
+		return "A"
+	case cards.Jack:
+		return "J"
+	case cards.Queen:
+		return "Q"
+	case cards.King:
+		return "K"
+	default:
+		return strconv.Itoa(int(rank))
+	}
+}
@@ -0,0 +1,136 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// pathOp is a single path drawing command
+type pathOp struct {
+	op  byte      // op is M (move), L (line), C (cubic curve), or Z (close)
+	pts []float64 // pts are the command's coordinates
+}
+
+// path is a filled vector shape
+type path struct {
+	ops  []pathOp
+	fill color.RGBA
+}
+
+// text is a line of text. The y coordinate is the text's baseline.
+type text struct {
+	x, y   float64
+	size   float64
+	bold   bool
+	center bool
+	fill   color.RGBA
+	s      string
+}
+
+// item is either a path or a text
+type item struct {
+	path *path
+	text *text
+}
+
+// scene is a drawing that can be written as SVG or rasterized as PNG
+type scene struct {
+	width, height float64
+	items         []item
+}
+
+// fillPath adds a filled path to the scene
+func (s *scene) fillPath(p *path) {
+	s.items = append(s.items, item{path: p})
+}
+
+// fillText adds text to the scene
+func (s *scene) fillText(t text) {
+	s.items = append(s.items, item{text: &t})
+}
+
+// roundRect adds a filled rectangle with rounded corners to the scene
+func (s *scene) roundRect(x, y, w, h, r float64, fill color.RGBA) {
+	const k = 0.5523 // k places cubic control points to approximate a quarter circle
+	p := &path{fill: fill}
+	p.move(x+r, y)
+	p.line(x+w-r, y)
+	p.cubic(x+w-r+k*r, y, x+w, y+r-k*r, x+w, y+r)
+	p.line(x+w, y+h-r)
+	p.cubic(x+w, y+h-r+k*r, x+w-r+k*r, y+h, x+w-r, y+h)
+	p.line(x+r, y+h)
+	p.cubic(x+r-k*r, y+h, x, y+h-r+k*r, x, y+h-r)
+	p.line(x, y+r)
+	p.cubic(x, y+r-k*r, x+r-k*r, y, x+r, y)
+	p.close()
+	s.fillPath(p)
+}
+
+// move starts a new subpath
+func (p *path) move(x, y float64) {
+	p.ops = append(p.ops, pathOp{op: 'M', pts: []float64{x, y}})
+}
+
+// line adds a straight line to the path
+func (p *path) line(x, y float64) {
+	p.ops = append(p.ops, pathOp{op: 'L', pts: []float64{x, y}})
+}
+
+// cubic adds a cubic Bézier curve to the path
+func (p *path) cubic(x1, y1, x2, y2, x, y float64) {
+	p.ops = append(p.ops, pathOp{op: 'C', pts: []float64{x1, y1, x2, y2, x, y}})
+}
+
+// close closes the current subpath
+func (p *path) close() {
+	p.ops = append(p.ops, pathOp{op: 'Z'})
+}
+
+// writeSVG writes the scene as an SVG document
+func (s *scene) writeSVG(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n",
+		s.width, s.height, s.width, s.height)
+	for _, it := range s.items {
+		switch {
+		case it.path != nil:
+			sb.WriteString(`<path d="`)
+			for i, op := range it.path.ops {
+				if i > 0 {
+					sb.WriteByte(' ')
+				}
+				sb.WriteByte(op.op)
+				for _, v := range op.pts {
+					fmt.Fprintf(&sb, " %.2f", v)
+				}
+			}
+			fmt.Fprintf(&sb, `" fill="%s"/>`+"\n", svgColor(it.path.fill))
+		case it.text != nil:
+			t := it.text
+			anchor := "start"
+			if t.center {
+				anchor = "middle"
+			}
+			weight := "normal"
+			if t.bold {
+				weight = "bold"
+			}
+			fmt.Fprintf(&sb, `<text x="%.2f" y="%.2f" font-family="Go, Helvetica, Arial, sans-serif" font-size="%g" font-weight="%s" text-anchor="%s" fill="%s">%s</text>`+"\n",
+				t.x, t.y, t.size, weight, anchor, svgColor(t.fill), html.EscapeString(t.s))
+		}
+	}
+	sb.WriteString("</svg>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// svgColor returns the color in SVG notation
+func svgColor(c color.RGBA) string {
+	if c.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("rgba(%d,%d,%d,%.3f)", c.R, c.G, c.B, float64(c.A)/0xff)
+}
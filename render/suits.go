@@ -0,0 +1,71 @@
+package render
+
+import (
+	"image/color"
+
+	"github.com/rbrabson/cards"
+)
+
+// suitPath returns the shape of a suit filling the square at (x, y) with the given size
+func suitPath(suit cards.Suit, x, y, size float64, fill color.RGBA) *path {
+	p := &path{fill: fill}
+	at := func(px, py float64) (float64, float64) {
+		return x + px*size, y + py*size
+	}
+	move := func(px, py float64) { p.move(at(px, py)) }
+	line := func(px, py float64) { p.line(at(px, py)) }
+	cubic := func(x1, y1, x2, y2, px, py float64) {
+		ax, ay := at(x1, y1)
+		bx, by := at(x2, y2)
+		cx, cy := at(px, py)
+		p.cubic(ax, ay, bx, by, cx, cy)
+	}
+	circle := func(cx, cy, r float64) {
+		const k = 0.5523
+		move(cx+r, cy)
+		cubic(cx+r, cy+k*r, cx+k*r, cy+r, cx, cy+r)
+		cubic(cx-k*r, cy+r, cx-r, cy+k*r, cx-r, cy)
+		cubic(cx-r, cy-k*r, cx-k*r, cy-r, cx, cy-r)
+		cubic(cx+k*r, cy-r, cx+r, cy-k*r, cx+r, cy)
+		p.close()
+	}
+
+	switch suit {
+	case cards.Diamonds:
+		move(0.5, 0)
+		line(0.9, 0.5)
+		line(0.5, 1)
+		line(0.1, 0.5)
+		p.close()
+	case cards.Hearts:
+		move(0.5, 1)
+		cubic(0.1, 0.65, 0, 0.45, 0, 0.28)
+		cubic(0, 0.1, 0.15, 0, 0.28, 0)
+		cubic(0.4, 0, 0.47, 0.08, 0.5, 0.18)
+		cubic(0.53, 0.08, 0.6, 0, 0.72, 0)
+		cubic(0.85, 0, 1, 0.1, 1, 0.28)
+		cubic(1, 0.45, 0.9, 0.65, 0.5, 1)
+		p.close()
+	case cards.Spades:
+		move(0.5, 0)
+		cubic(0.9, 0.35, 1, 0.5, 1, 0.65)
+		cubic(1, 0.82, 0.86, 0.9, 0.72, 0.9)
+		cubic(0.62, 0.9, 0.55, 0.85, 0.52, 0.78)
+		line(0.6, 1)
+		line(0.4, 1)
+		line(0.48, 0.78)
+		cubic(0.45, 0.85, 0.38, 0.9, 0.28, 0.9)
+		cubic(0.14, 0.9, 0, 0.82, 0, 0.65)
+		cubic(0, 0.5, 0.1, 0.35, 0.5, 0)
+		p.close()
+	case cards.Clubs:
+		circle(0.5, 0.26, 0.22)
+		circle(0.26, 0.58, 0.22)
+		circle(0.74, 0.58, 0.22)
+		move(0.5, 0.45)
+		line(0.62, 1)
+		line(0.38, 1)
+		p.close()
+	}
+	return p
+}
@@ -0,0 +1,203 @@
+package blackjack
+
+import (
+	"fmt"
+	"sort"
+)
+
+// replayStep is a single recorded action along with the hand it was taken on
+type replayStep struct {
+	seat   int // seat is the index of the player's seat, or -1 for the dealer
+	hand   int // hand is the index of the player's hand
+	action Action
+}
+
+// Replayer reconstructs a recorded round one action at a time, in the order the
+// actions originally occurred. It can be used to "watch" a round again or to
+// step through a round while reproducing a bug.
+type Replayer struct {
+	record  *RoundRecord
+	steps   []replayStep
+	pos     int
+	dealer  *Dealer
+	players []*Player
+	split   *Hand // split is the hand most recently split
+}
+
+// NewReplayer creates a replayer for the given round record
+func NewReplayer(record *RoundRecord) *Replayer {
+	r := &Replayer{record: record}
+	if len(record.Log) > 0 {
+		r.steps = logSteps(record)
+		r.Rewind()
+		return r
+	}
+
+	// Records without an action log are ordered by the time of each action
+	for _, action := range record.DealerActions {
+		r.steps = append(r.steps, replayStep{seat: -1, action: action})
+	}
+	for seatIdx, seat := range record.Seats {
+		for handIdx, hand := range seat.Hands {
+			for _, action := range hand.Actions {
+				r.steps = append(r.steps, replayStep{seat: seatIdx, hand: handIdx, action: action})
+			}
+		}
+	}
+	sort.SliceStable(r.steps, func(i, j int) bool {
+		return r.steps[i].action.Timestamp.Before(r.steps[j].action.Timestamp)
+	})
+
+	r.Rewind()
+	return r
+}
+
+// logSteps returns the steps of the round in the order of its action log
+func logSteps(record *RoundRecord) []replayStep {
+	seats := make(map[string]int, len(record.Seats))
+	for i, seat := range record.Seats {
+		seats[seat.Name] = i
+	}
+	steps := make([]replayStep, 0, len(record.Log))
+	for _, entry := range record.Log {
+		seat := -1
+		if !entry.IsDealer() {
+			idx, ok := seats[entry.Player]
+			if !ok {
+				continue
+			}
+			seat = idx
+		}
+		steps = append(steps, replayStep{seat: seat, hand: entry.Hand, action: entry.Action})
+	}
+	return steps
+}
+
+// Rewind returns the replay to the start of the round, before any cards are dealt
+func (r *Replayer) Rewind() {
+	r.pos = 0
+	r.dealer = NewDealer()
+	r.players = make([]*Player, 0, len(r.record.Seats))
+	for _, seat := range r.record.Seats {
+		player := NewPlayer(seat.Name)
+		player.spots = max(1, len(seat.Bets))
+		player.ClearHands()
+		for i, bet := range seat.Bets {
+			player.hands[i].SetBet(bet)
+		}
+		r.players = append(r.players, player)
+	}
+}
+
+// Step applies the next recorded action, returning false once the round has been
+// fully replayed
+func (r *Replayer) Step() (bool, error) {
+	if r.Done() {
+		return false, nil
+	}
+	step := r.steps[r.pos]
+	if err := r.apply(step); err != nil {
+		return false, fmt.Errorf("unable to replay action %d of round %d: %w", r.pos+1, r.record.ID, err)
+	}
+	r.pos++
+
+	return true, nil
+}
+
+// Done returns true if every recorded action has been replayed
+func (r *Replayer) Done() bool {
+	return r.pos >= len(r.steps)
+}
+
+// Position returns the number of actions replayed so far
+func (r *Replayer) Position() int {
+	return r.pos
+}
+
+// Len returns the total number of actions in the recorded round
+func (r *Replayer) Len() int {
+	return len(r.steps)
+}
+
+// LastAction returns the most recently replayed action along with the name of the
+// player who took it ("Dealer" for the dealer). The boolean is false if no action
+// has been replayed yet.
+func (r *Replayer) LastAction() (string, Action, bool) {
+	if r.pos == 0 {
+		return "", Action{}, false
+	}
+	step := r.steps[r.pos-1]
+	if step.seat < 0 {
+		return "Dealer", step.action, true
+	}
+	return r.record.Seats[step.seat].Name, step.action, true
+}
+
+// Dealer returns the dealer as of the current replay position
+func (r *Replayer) Dealer() *Dealer {
+	return r.dealer
+}
+
+// Players returns the players as of the current replay position
+func (r *Replayer) Players() []*Player {
+	result := make([]*Player, len(r.players))
+	copy(result, r.players)
+	return result
+}
+
+// apply applies a single recorded action to the reconstructed table
+func (r *Replayer) apply(step replayStep) error {
+	var hand *Hand
+	if step.seat < 0 {
+		hand = r.dealer.Hand()
+	} else {
+		player := r.players[step.seat]
+		switch {
+		case step.hand < len(player.hands):
+			hand = player.hands[step.hand]
+		case step.hand == len(player.hands):
+			// First action on a hand created by a split
+			hand = NewHand(player)
+			hand.isSplit = true
+			hand.id = len(player.hands) + 1
+			player.hands = append(player.hands, hand)
+		default:
+			return fmt.Errorf("hand %d of %s does not exist", step.hand+1, player.Name())
+		}
+		player.currentHandIdx = step.hand
+	}
+
+	action := step.action
+	switch action.Type {
+	case ActionDeal, ActionHit:
+		if action.Card == nil {
+			return fmt.Errorf("%s action is missing its card", action.Type)
+		}
+		hand.cards = append(hand.cards, *action.Card)
+	case ActionDouble:
+		if action.Card != nil {
+			hand.cards = append(hand.cards, *action.Card)
+		} else {
+			hand.bets.Double = hand.bets.Original
+		}
+	case ActionStand:
+		hand.isStood = true
+		hand.isActive = false
+	case ActionSplit:
+		if len(hand.cards) == 2 {
+			// The original hand gives up its second card to the new hand
+			hand.cards = hand.cards[:1]
+			hand.isSplit = true
+			r.split = hand
+		} else if r.split != nil {
+			hand.bets = BetComponents{Original: r.split.bets.Original}
+			hand.spot = r.split.spot
+			hand.parent = r.split
+		}
+	case ActionSurrender:
+		hand.isSurrendered = true
+	}
+	hand.actions = append(hand.actions, action)
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rbrabson/cards"
+)
+
+// ReplayFormatVersion is the version of the replay file format written by WriteReplay.
+// Version 2 records game results by name rather than by number, and version 3 names
+// the table rules in snake case; older files still read.
+const ReplayFormatVersion = 3
+
+// ReplayFile holds everything needed to play a sequence of recorded rounds again:
+// the cards dealt, each player's bets, and every decision they made, along with the
+// recorded outcomes so that playback can be validated.
+type ReplayFile struct {
+	Version int           `json:"version"`
+	GameID  string        `json:"game_id,omitempty"`
+	Rules   Rules         `json:"rules"`
+	Rounds  []ReplayRound `json:"rounds"`
+}
+
+// ReplayRound is a single recorded round in a replay file
+type ReplayRound struct {
+	Number  int            `json:"number"`
+	Cards   []cards.Card   `json:"cards"`   // Cards are the cards dealt from the shoe, in order
+	Players []ReplaySeat   `json:"players"` // Players are the players dealt into the round, in seat order
+	Actions []ReplayAction `json:"actions"` // Actions are the players' decisions, in the order they were made
+}
+
+// ReplaySeat is a player's bets and recorded outcomes in a replay round
+type ReplaySeat struct {
+	Name  string       `json:"name"`
+	Chips int          `json:"chips"` // Chips is the player's balance before the round's bets were placed
+	Bets  []int        `json:"bets"`  // Bets are the initial bets placed on each of the player's spots
+	Hands []ReplayHand `json:"hands"` // Hands are the recorded outcomes of the player's hands
+}
+
+// ReplayHand is the recorded outcome of a hand
+type ReplayHand struct {
+	Spot     int        `json:"spot"`
+	Bet      int        `json:"bet"`
+	Result   GameResult `json:"result"`
+	Winnings int        `json:"winnings"`
+}
+
+// ReplayAction is a decision made by a player
+type ReplayAction struct {
+	Player string     `json:"player"`
+	Action ActionType `json:"action"`
+}
+
+// NewReplayFile creates a replay file from completed round records, such as those
+// returned by Game.History
+func NewReplayFile(gameID string, rules Rules, records ...*RoundRecord) (*ReplayFile, error) {
+	file := &ReplayFile{Version: ReplayFormatVersion, GameID: gameID, Rules: rules}
+	for _, record := range records {
+		notation, err := NotationFromRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record round %d: %w", record.Number, err)
+		}
+		round := ReplayRound{
+			Number:  record.Number,
+			Cards:   append([]cards.Card(nil), record.Cards...),
+			Players: make([]ReplaySeat, 0, len(record.Seats)),
+		}
+		for _, seat := range record.Seats {
+			rs := ReplaySeat{Name: seat.Name, Chips: seat.Chips, Bets: append([]int(nil), seat.Bets...)}
+			for _, hand := range seat.Hands {
+				rs.Hands = append(rs.Hands, ReplayHand{Spot: hand.Spot, Bet: hand.Bet, Result: hand.Result, Winnings: hand.Winnings})
+			}
+			round.Players = append(round.Players, rs)
+		}
+		for _, seat := range notation.Seats {
+			for _, move := range seat.Moves {
+				round.Actions = append(round.Actions, ReplayAction{Player: seat.Name, Action: move.Action})
+			}
+		}
+		file.Rounds = append(file.Rounds, round)
+	}
+	return file, nil
+}
+
+// WriteReplay writes the replay file as JSON
+func WriteReplay(w io.Writer, file *ReplayFile) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return fmt.Errorf("failed to write replay: %w", err)
+	}
+	return nil
+}
+
+// ReadReplay reads a replay file written by WriteReplay
+func ReadReplay(r io.Reader) (*ReplayFile, error) {
+	var data json.RawMessage
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to read replay: %w", err)
+	}
+	file := &ReplayFile{}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to read replay: %w", err)
+	}
+	if file.Version > ReplayFormatVersion {
+		return nil, fmt.Errorf("replay format version %d is newer than the supported version %d", file.Version, ReplayFormatVersion)
+	}
+	if file.Version < 3 {
+		// Older files name the table rules by their Go field names
+		var legacy map[string]any
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return nil, fmt.Errorf("failed to read replay: %w", err)
+		}
+		migrateRules(legacy)
+		rules, err := json.Marshal(legacy["rules"])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read replay: %w", err)
+		}
+		file.Rules = Rules{}
+		if err := json.Unmarshal(rules, &file.Rules); err != nil {
+			return nil, fmt.Errorf("failed to read replay: %w", err)
+		}
+	}
+	return file, nil
+}
+
+// ReplayMismatch is a difference between a recorded outcome and the outcome of
+// playing the replay
+type ReplayMismatch struct {
+	Round    int    // Round is the number of the round
+	Player   string // Player is the name of the player
+	Hand     int    // Hand is the index of the player's hand, or -1 for the player's balance
+	Field    string // Field is what differed (hands, bet, result, winnings, or chips)
+	Recorded string // Recorded is the recorded value
+	Replayed string // Replayed is the value produced by playback
+}
+
+// String returns a description of the mismatch
+func (m ReplayMismatch) String() string {
+	where := m.Player
+	if m.Hand >= 0 {
+		where = fmt.Sprintf("%s hand %d", m.Player, m.Hand+1)
+	}
+	return fmt.Sprintf("round %d, %s: %s was %s but replayed as %s", m.Round, where, m.Field, m.Recorded, m.Replayed)
+}
+
+// PlayReplay plays every round in the replay file in a new game, dealing each round
+// from a shoe stacked with its recorded cards and making the recorded bets and
+// decisions. It returns the game along with every difference between the recorded
+// and replayed outcomes; a faithful replay has none. The options configure the game,
+// such as its payout policy; the table rules are taken from the replay file. An error
+// is returned if a round cannot be played at all.
+func PlayReplay(file *ReplayFile, options ...GameOption) (*Game, []ReplayMismatch, error) {
+	options = append(options, WithRules(file.Rules))
+	game := New(1, options...)
+
+	var mismatches []ReplayMismatch
+	for _, round := range file.Rounds {
+		roundMismatches, err := playReplayRound(game, round)
+		if err != nil {
+			return game, mismatches, fmt.Errorf("round %d: %w", round.Number, err)
+		}
+		mismatches = append(mismatches, roundMismatches...)
+	}
+	return game, mismatches, nil
+}
+
+// playReplayRound plays a single round of a replay and compares its outcome to the recording
+func playReplayRound(game *Game, round ReplayRound) ([]ReplayMismatch, error) {
+	var mismatches []ReplayMismatch
+	mismatch := func(player string, hand int, field string, recorded, replayed any) {
+		mismatches = append(mismatches, ReplayMismatch{
+			Round:    round.Number,
+			Player:   player,
+			Hand:     hand,
+			Field:    field,
+			Recorded: fmt.Sprint(recorded),
+			Replayed: fmt.Sprint(replayed),
+		})
+	}
+
+	// Players sit in the recorded seat order with their recorded balances
+	for _, player := range game.Players() {
+		game.RemovePlayer(player.Name())
+	}
+	for _, seat := range round.Players {
+		game.AddPlayer(seat.Name, WithChips(seat.Chips))
+	}
+
+	game.SetShoe(NewShoeFromCards(round.Cards))
+	if err := game.StartNewRound(); err != nil {
+		return nil, err
+	}
+	for _, seat := range round.Players {
+		if err := game.SetPlayerSpots(seat.Name, max(1, len(seat.Bets))); err != nil {
+			return nil, err
+		}
+		for spot, bet := range seat.Bets {
+			if err := game.PlaceBet(seat.Name, spot, bet); err != nil {
+				return nil, fmt.Errorf("%s: %w", seat.Name, err)
+			}
+		}
+	}
+	if err := game.DealInitialCards(); err != nil {
+		return nil, err
+	}
+	for i, action := range round.Actions {
+		if _, err := game.Act(action.Player, action.Action); err != nil {
+			return nil, fmt.Errorf("action %d (%s %s): %w", i+1, action.Player, action.Action, err)
+		}
+	}
+	if _, err := game.SettleIfFinished(); err != nil {
+		return nil, err
+	}
+	if game.Phase() != PhaseComplete {
+		return nil, fmt.Errorf("the round did not complete; players have hands left to play")
+	}
+
+	for _, seat := range round.Players {
+		player := game.GetPlayer(seat.Name)
+		hands := player.Hands()
+		if len(hands) != len(seat.Hands) {
+			mismatch(seat.Name, -1, "hands", len(seat.Hands), len(hands))
+			continue
+		}
+		for i, recorded := range seat.Hands {
+			hand := hands[i]
+			if hand.Bet() != recorded.Bet {
+				mismatch(seat.Name, i, "bet", recorded.Bet, hand.Bet())
+			}
+			if result := game.Evaluate(hand).Result; result != recorded.Result {
+				mismatch(seat.Name, i, "result", recorded.Result, result)
+			}
+			if hand.Winnings() != recorded.Winnings {
+				mismatch(seat.Name, i, "winnings", recorded.Winnings, hand.Winnings())
+			}
+		}
+		expected := seat.Chips
+		for _, hand := range seat.Hands {
+			expected += hand.Winnings
+		}
+		if player.Chips() != expected {
+			mismatch(seat.Name, -1, "chips", expected, player.Chips())
+		}
+	}
+	return mismatches, nil
+}
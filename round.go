@@ -0,0 +1,221 @@
+package blackjack
+
+import (
+	"time"
+
+	"github.com/rbrabson/cards"
+)
+
+const (
+	MaxRoundHistory = 100 // MaxRoundHistory is the number of completed rounds retained by a game
+)
+
+// RoundRecord captures everything needed to reconstruct a completed round
+type RoundRecord struct {
+	ID            int64        `json:"id"`                 // ID is the round's unique identifier within the game
+	Number        int          `json:"number"`             // Number is the round number within the session
+	StartedAt     time.Time    `json:"started_at"`         // StartedAt is when the round was started
+	EndedAt       time.Time    `json:"ended_at,omitempty"` // EndedAt is when the round was settled
+	Cards         []cards.Card `json:"cards"`              // Cards is the sequence of cards drawn from the shoe
+	Seats         []SeatRecord `json:"seats"`              // Seats are the players dealt into the round, in seat order
+	DealerCards   []cards.Card `json:"dealer_cards"`       // DealerCards are the dealer's final cards
+	DealerActions []Action     `json:"dealer_actions"`     // DealerActions are the actions taken on the dealer's hand
+	Rules         *Rules       `json:"rules,omitempty"`    // Rules are the table rules the round was played under
+
+	Log []LoggedAction `json:"log,omitempty"` // Log is every action taken in the round, in the order it was taken
+}
+
+// SeatRecord captures a single player's participation in a round
+type SeatRecord struct {
+	Name  string       `json:"name"`  // Name is the player's name
+	Chips int          `json:"chips"` // Chips is the player's balance before the round's bets were placed
+	Bets  []int        `json:"bets"`  // Bets are the initial bets placed on each of the player's spots
+	Hands []HandRecord `json:"hands"` // Hands are the player's settled hands, including any split hands
+
+	DecisionTimes []time.Duration `json:"decision_times"` // DecisionTimes are how long the player took to make each decision
+}
+
+// HandRecord captures a single settled hand
+type HandRecord struct {
+	ID       int           `json:"id,omitempty"`     // ID is the hand's number among the player's hands, starting at 1
+	ParentID int           `json:"parent,omitempty"` // ParentID is the ID of the hand this hand was split from, or 0
+	Spot     int           `json:"spot"`             // Spot is the player's spot the hand was played on
+	Cards    []cards.Card  `json:"cards"`            // Cards are the hand's final cards
+	Bet      int           `json:"bet"`              // Bet is the final bet on the hand, including any double down
+	Bets     BetComponents `json:"bets,omitzero"`    // Bets are the wagers that make up the hand's bet
+	Result   GameResult    `json:"result"`           // Result is the outcome of the hand
+	Winnings int           `json:"winnings"`         // Winnings is the net amount won (negative for a loss)
+	Actions  []Action      `json:"actions"`          // Actions are the actions taken on the hand
+}
+
+// Duration returns how long the round took from start to settlement
+func (r *RoundRecord) Duration() time.Duration {
+	if r.EndedAt.IsZero() {
+		return 0
+	}
+	return r.EndedAt.Sub(r.StartedAt)
+}
+
+// seat returns the record of the named player's seat, or nil if they were not dealt in
+func (r *RoundRecord) seat(name string) *SeatRecord {
+	for i := range r.Seats {
+		if r.Seats[i].Name == name {
+			return &r.Seats[i]
+		}
+	}
+	return nil
+}
+
+// renamePlayer attributes the player's seat and actions in the round to a new name
+func (r *RoundRecord) renamePlayer(name string, newName string) {
+	for i := range r.Seats {
+		if r.Seats[i].Name == name {
+			r.Seats[i].Name = newName
+		}
+	}
+	for i := range r.Log {
+		if r.Log[i].Player == name {
+			r.Log[i].Player = newName
+		}
+	}
+}
+
+// Net returns the net amount the player won in the round across all of their hands
+// (negative for a loss)
+func (s *SeatRecord) Net() int {
+	net := 0
+	for _, hand := range s.Hands {
+		net += hand.Winnings
+	}
+	return net
+}
+
+// AverageDecisionTime returns the average time the player took to make a decision
+func (s *SeatRecord) AverageDecisionTime() time.Duration {
+	if len(s.DecisionTimes) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range s.DecisionTimes {
+		total += d
+	}
+	return total / time.Duration(len(s.DecisionTimes))
+}
+
+// newRoundRecord creates the record for a newly started round
+func newRoundRecord(id int64, number int, startedAt time.Time, rules Rules) *RoundRecord {
+	return &RoundRecord{
+		ID:        id,
+		Number:    number,
+		StartedAt: startedAt,
+		Cards:     make([]cards.Card, 0, 16),
+		Rules:     &rules,
+	}
+}
+
+// rules returns the rules the round was played under, or the default rules for a
+// record that predates recording them
+func (r *RoundRecord) rules() Rules {
+	if r.Rules == nil {
+		return DefaultRules()
+	}
+	return *r.Rules
+}
+
+// recordSeats records the players that were dealt into the round along with their bets
+func (r *RoundRecord) recordSeats(players []*Player) {
+	r.Seats = make([]SeatRecord, 0, len(players))
+	for _, player := range players {
+		if !player.IsActive() {
+			continue
+		}
+		seat := SeatRecord{Name: player.Name(), Chips: player.Chips()}
+		for _, hand := range player.Hands() {
+			seat.Bets = append(seat.Bets, hand.Bet())
+			seat.Chips += hand.Bet()
+		}
+		r.Seats = append(r.Seats, seat)
+	}
+}
+
+// complete records the final state of every hand once the round is settled
+func (r *RoundRecord) complete(players []*Player, dealer *Dealer, evaluate func(*Hand) Evaluation) {
+	r.EndedAt = time.Now()
+	r.DealerCards = dealer.Hand().Cards()
+	r.DealerActions = copyActions(dealer.Hand().actions)
+	for i := range r.Seats {
+		seat := &r.Seats[i]
+		for _, player := range players {
+			if player.Name() != seat.Name {
+				continue
+			}
+			seat.Hands = make([]HandRecord, 0, len(player.Hands()))
+			for _, hand := range player.Hands() {
+				seat.Hands = append(seat.Hands, newHandRecord(hand, handResult(hand, evaluate)))
+			}
+			break
+		}
+	}
+}
+
+// newHandRecord freezes the hand into a record that shares no memory with it, so
+// that clearing or reusing the hand does not change the record
+func newHandRecord(hand *Hand, result GameResult) HandRecord {
+	return HandRecord{
+		ID:       hand.ID(),
+		ParentID: hand.ParentID(),
+		Spot:     hand.Spot(),
+		Cards:    hand.Cards(),
+		Bet:      hand.Bet(),
+		Bets:     hand.Bets(),
+		Result:   result,
+		Winnings: hand.Winnings(),
+		Actions:  copyActions(hand.actions),
+	}
+}
+
+// Clone returns a deep copy of the hand record
+func (h HandRecord) Clone() HandRecord {
+	h.Cards = append([]cards.Card(nil), h.Cards...)
+	h.Bets = h.Bets.clone()
+	h.Actions = copyActions(h.Actions)
+	return h
+}
+
+// Clone returns a deep copy of the round record, so that changes made to the copy
+// do not affect the game's history
+func (r *RoundRecord) Clone() *RoundRecord {
+	if r == nil {
+		return nil
+	}
+	clone := *r
+	clone.Cards = append([]cards.Card(nil), r.Cards...)
+	clone.DealerCards = append([]cards.Card(nil), r.DealerCards...)
+	clone.DealerActions = copyActions(r.DealerActions)
+	clone.Log = copyLog(r.Log)
+	if r.Rules != nil {
+		rules := *r.Rules
+		clone.Rules = &rules
+	}
+	clone.Seats = make([]SeatRecord, len(r.Seats))
+	for i, seat := range r.Seats {
+		seat.Bets = append([]int(nil), seat.Bets...)
+		seat.DecisionTimes = append([]time.Duration(nil), seat.DecisionTimes...)
+		hands := make([]HandRecord, len(seat.Hands))
+		for j, hand := range seat.Hands {
+			hands[j] = hand.Clone()
+		}
+		seat.Hands = hands
+		clone.Seats[i] = seat
+	}
+	return &clone
+}
+
+// handResult returns the outcome the hand was settled with, evaluating it if it
+// has not been settled
+func handResult(hand *Hand, evaluate func(*Hand) Evaluation) GameResult {
+	if hand.IsSettled() {
+		return hand.Result()
+	}
+	return evaluate(hand).Result
+}
@@ -0,0 +1,130 @@
+package blackjack
+
+import "fmt"
+
+// MaxHandsPerSpot is the most hands a spot may ever be split into
+const MaxHandsPerSpot = 4
+
+// Rules are the table rules used by a game
+type Rules struct {
+	DealerPeek   bool `json:"dealer_peek"`   // DealerPeek is whether the dealer checks for blackjack when showing an ace or ten-value card
+	MinBet       int  `json:"min_bet"`       // MinBet is the smallest bet allowed (0 for no minimum)
+	MaxBet       int  `json:"max_bet"`       // MaxBet is the largest bet allowed (0 for no maximum)
+	BetIncrement int  `json:"bet_increment"` // BetIncrement is the multiple that all bets must be made in (0 for any amount)
+	MaxSpots     int  `json:"max_spots"`     // MaxSpots is the number of spots a single player may play at once (0 for one spot)
+
+	NoSurrender         bool `json:"no_surrender"`          // NoSurrender is whether surrendering is forbidden
+	SurrenderAfterSplit bool `json:"surrender_after_split"` // SurrenderAfterSplit is whether a hand that was split may be surrendered
+	MaxSplitHands       int  `json:"max_split_hands"`       // MaxSplitHands is the most hands a spot may be split into, such as 2 to forbid resplitting (0 for MaxHandsPerSpot)
+	DoubleMin           int  `json:"double_min"`            // DoubleMin is the lowest hand value that may be doubled down on (0 for no minimum)
+	DoubleMax           int  `json:"double_max"`            // DoubleMax is the highest hand value that may be doubled down on (0 for no maximum)
+	NoDoubleAfterSplit  bool `json:"no_double_after_split"` // NoDoubleAfterSplit is whether doubling down is forbidden on a hand that was split
+	ResplitAces         bool `json:"resplit_aces"`          // ResplitAces is whether a split ace dealt another ace may be split again
+	HitSplitAces        bool `json:"hit_split_aces"`        // HitSplitAces is whether split aces are played like any other hand rather than receiving only one card each
+
+	StandSoft17     bool        `json:"stand_soft_17"`    // StandSoft17 is whether the dealer stands on a soft 17 rather than hitting it
+	BlackjackPayout PayoutRatio `json:"blackjack_payout"` // BlackjackPayout is what a player's blackjack pays (3:2 if not set)
+	CharlieCards    int         `json:"charlie_cards"`    // CharlieCards is the number of cards that wins a hand without busting, such as 5 for a five-card Charlie (0 for no Charlie)
+}
+
+// DefaultRules returns the standard table rules
+func DefaultRules() Rules {
+	return Rules{
+		DealerPeek: true,
+		MaxSpots:   3,
+	}
+}
+
+// WithRules sets the table rules used by the game.
+func WithRules(rules Rules) GameOption {
+	return func(g *Game) {
+		g.rules = rules
+	}
+}
+
+// Validate returns an error if the rules are inconsistent or a limit is out of
+// range
+func (r Rules) Validate() error {
+	switch {
+	case r.MinBet < 0 || r.MaxBet < 0 || r.BetIncrement < 0:
+		return fmt.Errorf("bet limits must not be negative")
+	case r.MaxBet > 0 && r.MaxBet < r.MinBet:
+		return fmt.Errorf("the maximum bet of %d is below the minimum bet of %d", r.MaxBet, r.MinBet)
+	case r.MaxSpots < 0:
+		return fmt.Errorf("spots must not be negative")
+	case r.MaxSplitHands < 0 || r.MaxSplitHands > MaxHandsPerSpot:
+		return fmt.Errorf("split hands must be from 0 to %d", MaxHandsPerSpot)
+	case r.DoubleMin < 0 || r.DoubleMax < 0:
+		return fmt.Errorf("double down limits must not be negative")
+	case r.DoubleMax > 0 && r.DoubleMax < r.DoubleMin:
+		return fmt.Errorf("the highest hand value to double down on, %d, is below the lowest, %d", r.DoubleMax, r.DoubleMin)
+	case r.BlackjackPayout != (PayoutRatio{}) && (r.BlackjackPayout.Win <= 0 || r.BlackjackPayout.Bet <= 0):
+		return fmt.Errorf("invalid blackjack payout %s", r.BlackjackPayout)
+	case r.CharlieCards < 0 || r.CharlieCards == 1 || r.CharlieCards == 2:
+		return fmt.Errorf("a Charlie must be at least 3 cards, or 0 for no Charlie")
+	}
+	return nil
+}
+
+// ValidateBet returns an error if the bet amount is not allowed by the table limits
+func (r Rules) ValidateBet(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("bet must be positive")
+	}
+	if r.MinBet > 0 && amount < r.MinBet {
+		return fmt.Errorf("bet of %d is below the table minimum of %d", amount, r.MinBet)
+	}
+	if r.MaxBet > 0 && amount > r.MaxBet {
+		return fmt.Errorf("bet of %d is above the table maximum of %d", amount, r.MaxBet)
+	}
+	if r.BetIncrement > 0 && amount%r.BetIncrement != 0 {
+		return fmt.Errorf("bet of %d is not a multiple of %d", amount, r.BetIncrement)
+	}
+	return nil
+}
+
+// AllowsHit returns true if the rules allow the hand to take another card. Split
+// aces receive only one card each unless HitSplitAces is set.
+func (r Rules) AllowsHit(h *Hand) bool {
+	if h.isStood || h.IsBusted() {
+		return false
+	}
+	return !h.isSplitAces() || r.HitSplitAces
+}
+
+// AllowsDouble returns true if the rules allow doubling down on the hand, without
+// regard to the player's chips. Split aces may only be doubled down on if they may
+// be hit.
+func (r Rules) AllowsDouble(h *Hand) bool {
+	if len(h.cards) != 2 || h.isStood {
+		return false
+	}
+	if h.isSplit && (r.NoDoubleAfterSplit || (h.isSplitAces() && !r.HitSplitAces)) {
+		return false
+	}
+	value := h.Value()
+	if r.DoubleMin > 0 && value < r.DoubleMin {
+		return false
+	}
+	if r.DoubleMax > 0 && value > r.DoubleMax {
+		return false
+	}
+	return true
+}
+
+// maxSplitHands returns the most hands a spot may be split into
+func (r Rules) maxSplitHands() int {
+	if r.MaxSplitHands <= 0 || r.MaxSplitHands > MaxHandsPerSpot {
+		return MaxHandsPerSpot
+	}
+	return r.MaxSplitHands
+}
+
+// BlackjackPays returns what a player's blackjack pays: the table's blackjack
+// payout, or 3:2 if it is not set
+func (r Rules) BlackjackPays() PayoutRatio {
+	if r.BlackjackPayout.Win <= 0 || r.BlackjackPayout.Bet <= 0 {
+		return ThreeToTwo
+	}
+	return r.BlackjackPayout
+}
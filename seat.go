@@ -0,0 +1,176 @@
+package blackjack
+
+import (
+	"context"
+	"fmt"
+)
+
+// Seat makes the decisions for a player, so that the game can drive players that
+// are not controlled through direct calls, such as remote players or computer
+// players with their own internal state. A player is given a seat with WithSeat,
+// and PlayRound asks each player's seat for their bets and actions.
+type Seat interface {
+	Bet(ctx context.Context, decision BetDecision) (int, error)           // Bet returns the amount to bet on a spot, or 0 to sit the spot out
+	Act(ctx context.Context, decision ActionDecision) (ActionType, error) // Act returns the action to take on the player's current hand
+}
+
+// BetDecision is the information given to a seat when it places a bet
+type BetDecision struct {
+	Player string    // Player is the name of the player betting
+	Spot   int       // Spot is the index of the spot being bet on
+	Chips  int       // Chips is the player's available chips
+	Rules  Rules     // Rules are the table rules, including the betting limits
+	State  GameState // State is the state of the table
+}
+
+// ActionDecision is the information given to a seat when it acts on a hand
+type ActionDecision struct {
+	Player  string       // Player is the name of the player acting
+	Hand    int          // Hand is the index of the player's current hand
+	Actions []ActionType // Actions are the actions the player may take on the hand
+	Rules   Rules        // Rules are the table rules
+	State   GameState    // State is the state of the table, with the dealer's hole card hidden
+}
+
+// SeatFuncs adapts a pair of ordinary functions to the Seat interface
+type SeatFuncs struct {
+	BetFunc func(ctx context.Context, decision BetDecision) (int, error)
+	ActFunc func(ctx context.Context, decision ActionDecision) (ActionType, error)
+}
+
+// Bet calls s.BetFunc(ctx, decision)
+func (s SeatFuncs) Bet(ctx context.Context, decision BetDecision) (int, error) {
+	return s.BetFunc(ctx, decision)
+}
+
+// Act calls s.ActFunc(ctx, decision)
+func (s SeatFuncs) Act(ctx context.Context, decision ActionDecision) (ActionType, error) {
+	return s.ActFunc(ctx, decision)
+}
+
+// WithSeat sets the seat that makes the player's decisions when the game is
+// played with PlayRound
+func WithSeat(seat Seat) Option {
+	return func(p *Player) {
+		p.seat = seat
+	}
+}
+
+// Seat returns the seat that makes the player's decisions, or nil if the player is
+// controlled through direct calls
+func (p *Player) Seat() Seat {
+	return p.seat
+}
+
+// SetSeat sets the seat that makes the player's decisions
+func (p *Player) SetSeat(seat Seat) {
+	p.seat = seat
+}
+
+// AvailableActions returns the actions the player may take on their current hand
+func (p *Player) AvailableActions() []ActionType {
+	if !p.active || p.IsStanding() {
+		return nil
+	}
+	hand := p.CurrentHand()
+	actions := []ActionType{ActionStand}
+	if hand.CanHit() {
+		actions = []ActionType{ActionHit, ActionStand}
+	}
+	if hand.CanDoubleDown() {
+		actions = append(actions, ActionDouble)
+	}
+	if hand.CanSplit() {
+		actions = append(actions, ActionSplit)
+	}
+	if hand.CanSurrender() {
+		actions = append(actions, ActionSurrender)
+	}
+	return actions
+}
+
+// PlayRound plays a complete round, asking each player's seat for their bets and
+// actions, and returns the record of the round. Every player must have a seat. If
+// a seat fails, or the context is cancelled, the round is aborted and its bets
+// are returned.
+func (bg *Game) PlayRound(ctx context.Context) (*RoundRecord, error) {
+	for _, player := range bg.players {
+		if player.seat == nil {
+			return nil, fmt.Errorf("player %s does not have a seat", player.Name())
+		}
+	}
+
+	if err := bg.StartNewRound(); err != nil {
+		return nil, err
+	}
+	if err := bg.collectBets(ctx); err != nil {
+		return nil, bg.abortAfter(err)
+	}
+	if err := bg.DealInitialCards(); err != nil {
+		return nil, bg.abortAfter(err)
+	}
+
+	for {
+		player := bg.GetActivePlayer()
+		if player == nil {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, bg.abortAfter(err)
+		}
+		decision := ActionDecision{
+			Player:  player.Name(),
+			Hand:    player.currentHandIdx,
+			Actions: player.AvailableActions(),
+			Rules:   bg.rules,
+			State:   bg.State(),
+		}
+		action, err := player.seat.Act(ctx, decision)
+		if err != nil {
+			return nil, bg.abortAfter(fmt.Errorf("seat for %s failed to act: %w", player.Name(), err))
+		}
+		if _, err := bg.ActContext(ctx, player.Name(), action); err != nil {
+			return nil, bg.abortAfter(err)
+		}
+	}
+
+	if _, err := bg.SettleIfFinished(); err != nil {
+		return nil, err
+	}
+	return bg.LastRound(), nil
+}
+
+// collectBets asks each player's seat for a bet on each of their spots. Players
+// who bet on no spots sit the round out.
+func (bg *Game) collectBets(ctx context.Context) error {
+	for _, player := range bg.players {
+		betting := false
+		for spot := range player.spots {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			decision := BetDecision{
+				Player: player.Name(),
+				Spot:   spot,
+				Chips:  player.Chips(),
+				Rules:  bg.rules,
+				State:  bg.State(),
+			}
+			amount, err := player.seat.Bet(ctx, decision)
+			if err != nil {
+				return fmt.Errorf("seat for %s failed to bet: %w", player.Name(), err)
+			}
+			if amount == 0 {
+				continue
+			}
+			if err := bg.PlaceBetContext(ctx, player.Name(), spot, amount); err != nil {
+				return err
+			}
+			betting = true
+		}
+		if !betting {
+			player.SetActive(false)
+		}
+	}
+	return nil
+}
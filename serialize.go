@@ -0,0 +1,359 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"time"
+
+	"github.com/rbrabson/cards"
+)
+
+// gameSnapshot is the serialized form of a game
+type gameSnapshot struct {
+	Version        int              `json:"version"`
+	ID             string           `json:"id"`
+	Rules          Rules            `json:"rules"`
+	Phase          Phase            `json:"phase"`
+	Round          int              `json:"round"`
+	RoundID        int64            `json:"round_id"`
+	RoundStarted   time.Time        `json:"round_started"`
+	ActionInterval time.Duration    `json:"action_interval,omitempty"`
+	Currency       *Currency        `json:"currency,omitempty"`
+	Bonus          *Bonus           `json:"bonus,omitempty"`
+	ShufflePolicy  ShufflePolicy    `json:"shuffle_policy,omitempty"`
+	Shoe           shoeSnapshot     `json:"shoe"`
+	Dealer         dealerSnapshot   `json:"dealer"`
+	Players        []playerSnapshot `json:"players"`
+	Record         *RoundRecord     `json:"record,omitempty"`
+	History        []*RoundRecord   `json:"history,omitempty"`
+	Idempotency    []keyedResult    `json:"idempotency,omitempty"` // Idempotency are the remembered results of keyed requests, oldest first
+}
+
+// keyedResult is the serialized form of the remembered result of a keyed request
+type keyedResult struct {
+	Player  string        `json:"player"`
+	Key     string        `json:"key"`
+	Request string        `json:"request"`
+	Outcome ActionOutcome `json:"outcome"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// shoeSnapshot is the serialized form of a shoe
+type shoeSnapshot struct {
+	NumDecks    int          `json:"num_decks"`
+	CutCard     int          `json:"cut_card"`
+	Penetration float64      `json:"penetration,omitempty"` // Penetration is the fraction of the shoe dealt before reshuffling, if not the default
+	Cards       []cards.Card `json:"cards"`
+	RNG         []byte       `json:"rng,omitempty"` // RNG is the state of the seeded source the shoe is shuffled from, if seeded
+}
+
+// dealerSnapshot is the serialized form of the dealer
+type dealerSnapshot struct {
+	Hand             handSnapshot `json:"hand"`
+	HoleCardRevealed bool         `json:"hole_card_revealed"`
+}
+
+// playerSnapshot is the serialized form of a player
+type playerSnapshot struct {
+	Name          string            `json:"name"`
+	Chips         int               `json:"chips"`
+	StartingChips int               `json:"starting_chips"`
+	Spots         int               `json:"spots"`
+	Active        bool              `json:"active"`
+	Waiting       bool              `json:"waiting,omitempty"`
+	LastBonus     time.Time         `json:"last_bonus,omitzero"`
+	Stats         PlayerStats       `json:"stats,omitzero"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	CurrentHand   int               `json:"current_hand"`
+	Hands         []handSnapshot    `json:"hands"`
+}
+
+// handSnapshot is the serialized form of a hand
+type handSnapshot struct {
+	Cards       []cards.Card  `json:"cards"`
+	Split       bool          `json:"split,omitempty"`
+	Active      bool          `json:"active"`
+	Stood       bool          `json:"stood,omitempty"`
+	Surrendered bool          `json:"surrendered,omitempty"`
+	Actions     []Action      `json:"actions,omitempty"`
+	Bet         int           `json:"bet"`
+	Bets        BetComponents `json:"bets,omitzero"`
+	Winnings    int           `json:"winnings"`
+	Spot        int           `json:"spot"`
+	Parent      int           `json:"parent"` // Parent is the index of the hand this hand was split from, or -1
+	ID          int           `json:"id,omitempty"`
+	Result      GameResult    `json:"result,omitempty"`
+	SettledAt   time.Time     `json:"settled_at,omitzero"`
+}
+
+// Save writes the complete state of the game as JSON, so that it can later be
+// resumed with Load
+func (bg *Game) Save(w io.Writer) error {
+	snapshot, err := bg.saveState()
+	if err != nil {
+		return fmt.Errorf("failed to save game %s: %w", bg.id, err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to save game %s: %w", bg.id, err)
+	}
+	return nil
+}
+
+// Load reads a game previously written by Save. Options are applied to the loaded
+// game to restore settings that are not saved, such as the payout policy and
+// listeners. Players' chips are restored into a DefaultChipManager; a custom chip
+// manager may be set with Player.SetChipManager.
+func Load(r io.Reader, options ...GameOption) (*Game, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+	game := New(1)
+	if err := game.restore(data); err != nil {
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+	for _, option := range options {
+		option(game)
+	}
+	return game, nil
+}
+
+// MarshalJSON returns the JSON representation of the game's state. The dealer's
+// hole card is omitted until it has been revealed and the order of the shoe is
+// never included; use Save for the complete state of the game.
+func (bg *Game) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bg.State())
+}
+
+// saveState returns the complete state of the game, as written by Save
+func (bg *Game) saveState() (gameSnapshot, error) {
+	snapshot := gameSnapshot{
+		Version:        SaveFormatVersion,
+		ID:             bg.id,
+		Rules:          bg.rules,
+		Phase:          bg.phase,
+		Round:          bg.round,
+		RoundID:        bg.roundID,
+		RoundStarted:   bg.roundStarted,
+		ActionInterval: bg.actionInterval,
+		ShufflePolicy:  bg.shufflePolicy,
+		Shoe: shoeSnapshot{
+			NumDecks:    bg.shoe.numDecks,
+			CutCard:     bg.shoe.cutCard,
+			Penetration: bg.shoe.penetration,
+			Cards:       bg.shoe.cards,
+		},
+		Dealer: dealerSnapshot{
+			Hand:             snapshotHand(bg.dealer.hand, nil),
+			HoleCardRevealed: bg.dealer.holeCardRevealed,
+		},
+		Players: make([]playerSnapshot, 0, len(bg.players)),
+		Record:  bg.record,
+		History: bg.history,
+	}
+	if bg.currency != PlainChips {
+		snapshot.Currency = &bg.currency
+	}
+	if bg.bonus.Amount > 0 {
+		snapshot.Bonus = &bg.bonus
+	}
+	if bg.shoe.pcg != nil {
+		rng, err := bg.shoe.pcg.MarshalBinary()
+		if err != nil {
+			return gameSnapshot{}, err
+		}
+		snapshot.Shoe.RNG = rng
+	}
+	for _, id := range bg.idempotencyKeys {
+		result := bg.idempotencyResults[id]
+		kr := keyedResult{Player: id.player, Key: id.key, Request: result.request, Outcome: result.outcome}
+		if result.err != nil {
+			kr.Error = result.err.Error()
+		}
+		snapshot.Idempotency = append(snapshot.Idempotency, kr)
+	}
+	for _, player := range bg.players {
+		ps := playerSnapshot{
+			Name:          player.name,
+			Chips:         player.Chips(),
+			StartingChips: player.startingChips,
+			Spots:         player.spots,
+			Active:        player.active,
+			Waiting:       player.waiting,
+			LastBonus:     player.lastBonus,
+			Stats:         player.Stats(),
+			Metadata:      player.metadata,
+			CurrentHand:   player.currentHandIdx,
+			Hands:         make([]handSnapshot, 0, len(player.hands)),
+		}
+		for _, hand := range player.hands {
+			ps.Hands = append(ps.Hands, snapshotHand(hand, player.hands))
+		}
+		snapshot.Players = append(snapshot.Players, ps)
+	}
+
+	return snapshot, nil
+}
+
+// restore restores the state of the game from JSON written by Save.
+// Saves written in an older format version are migrated to the current version
+// first. Settings that are not serialized, such as the payout policy and listeners,
+// are left unchanged.
+func (bg *Game) restore(data []byte) error {
+	data, err := migrateSave(data)
+	if err != nil {
+		return err
+	}
+
+	var snapshot gameSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+
+	bg.id = snapshot.ID
+	bg.rules = snapshot.Rules
+	bg.phase = snapshot.Phase
+	bg.round = snapshot.Round
+	bg.roundID = snapshot.RoundID
+	bg.roundStarted = snapshot.RoundStarted
+	bg.actionInterval = snapshot.ActionInterval
+	bg.shufflePolicy = snapshot.ShufflePolicy
+	bg.currency = PlainChips
+	if snapshot.Currency != nil {
+		bg.currency = *snapshot.Currency
+	}
+	bg.bonus = Bonus{}
+	if snapshot.Bonus != nil {
+		bg.bonus = *snapshot.Bonus
+	}
+	bg.record = snapshot.Record
+	bg.history = snapshot.History
+	bg.idempotencyResults = nil
+	bg.idempotencyKeys = nil
+	for _, kr := range snapshot.Idempotency {
+		result := idempotentResult{request: kr.Request, outcome: kr.Outcome}
+		if kr.Error != "" {
+			result.err = errors.New(kr.Error)
+		}
+		bg.rememberResult(idempotencyKey{player: kr.Player, key: kr.Key}, result)
+	}
+	if bg.payout == nil {
+		bg.payout = DefaultPayoutPolicy{}
+	}
+
+	bg.shoe = &Shoe{
+		cards:       cards.Shoe(snapshot.Shoe.Cards),
+		numDecks:    max(1, snapshot.Shoe.NumDecks),
+		cutCard:     snapshot.Shoe.CutCard,
+		penetration: snapshot.Shoe.Penetration,
+	}
+	if len(snapshot.Shoe.RNG) > 0 {
+		pcg := &rand.PCG{}
+		if err := pcg.UnmarshalBinary(snapshot.Shoe.RNG); err != nil {
+			return fmt.Errorf("invalid shoe shuffle state: %w", err)
+		}
+		bg.shoe.setSource(pcg)
+	}
+
+	bg.dealer = NewDealer()
+	bg.dealer.game = bg
+	bg.dealer.hand = restoreHand(snapshot.Dealer.Hand, nil)
+	bg.dealer.hand.dealer = bg.dealer
+	bg.dealer.holeCardRevealed = snapshot.Dealer.HoleCardRevealed
+
+	bg.players = make([]*Player, 0, len(snapshot.Players))
+	for _, ps := range snapshot.Players {
+		player := NewPlayer(ps.Name, WithChips(ps.Chips))
+		player.game = bg
+		player.startingChips = ps.StartingChips
+		player.spots = max(1, ps.Spots)
+		player.active = ps.Active
+		player.waiting = ps.Waiting
+		player.lastBonus = ps.LastBonus
+		player.stats = ps.Stats
+		player.metadata = ps.Metadata
+		player.hands = make([]*Hand, 0, len(ps.Hands))
+		for _, hs := range ps.Hands {
+			player.hands = append(player.hands, restoreHand(hs, player))
+		}
+		for i, hs := range ps.Hands {
+			if hs.Parent >= 0 && hs.Parent < len(player.hands) {
+				player.hands[i].parent = player.hands[hs.Parent]
+			}
+			if player.hands[i].id == 0 {
+				player.hands[i].id = i + 1
+			}
+		}
+		if len(player.hands) == 0 {
+			player.ClearHands()
+		}
+		if ps.CurrentHand >= 0 && ps.CurrentHand < len(player.hands) {
+			player.currentHandIdx = ps.CurrentHand
+		}
+		if cm, ok := player.chipManager.(*DefaultChipManager); ok && bg.roundInProgress() {
+			// Bets of a round in progress are held in escrow until they are settled
+			cm.reserved = player.outstandingBets()
+		}
+		bg.players = append(bg.players, player)
+	}
+
+	// Time a resumed player's decision from when the game was loaded
+	bg.lastDecision = time.Now()
+
+	return nil
+}
+
+// snapshotHand returns the serialized form of a hand. The player's hands are used
+// to record which hand, if any, the hand was split from.
+func snapshotHand(h *Hand, siblings []*Hand) handSnapshot {
+	hs := handSnapshot{
+		Cards:       h.Cards(),
+		Split:       h.isSplit,
+		Active:      h.isActive,
+		Stood:       h.isStood,
+		Surrendered: h.isSurrendered,
+		Actions:     h.Actions(),
+		Bet:         h.Bet(),
+		Bets:        h.bets.clone(),
+		Winnings:    h.winnings,
+		Spot:        h.spot,
+		Parent:      -1,
+		ID:          h.id,
+		Result:      h.result,
+		SettledAt:   h.settledAt,
+	}
+	for i, sibling := range siblings {
+		if sibling == h.parent {
+			hs.Parent = i
+			break
+		}
+	}
+	return hs
+}
+
+// restoreHand creates a hand from its serialized form
+func restoreHand(hs handSnapshot, player *Player) *Hand {
+	h := NewHand(player)
+	h.cards = append(h.cards, hs.Cards...)
+	h.isSplit = hs.Split
+	h.isActive = hs.Active
+	h.isStood = hs.Stood
+	h.isSurrendered = hs.Surrendered
+	h.actions = append(h.actions, hs.Actions...)
+	h.bets = hs.Bets.clone()
+	if h.bets.Main() == 0 {
+		// Snapshots taken before bets were broken down only record the total
+		h.bets.Original = hs.Bet
+	}
+	h.winnings = hs.Winnings
+	h.spot = hs.Spot
+	h.id = hs.ID
+	h.result = hs.Result
+	h.settledAt = hs.SettledAt
+	return h
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	stdhttp "net/http"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/server/ws"
+)
+
+// ErrorCode identifies the kind of error returned by the API
+type ErrorCode string
+
+const (
+	CodeInvalidRequest ErrorCode = "invalid_request"  // CodeInvalidRequest indicates a malformed request body or parameter
+	CodeTableNotFound  ErrorCode = "table_not_found"  // CodeTableNotFound indicates the table does not exist
+	CodePlayerNotFound ErrorCode = "player_not_found" // CodePlayerNotFound indicates the player is not seated at the table
+	CodePlayerExists   ErrorCode = "player_exists"    // CodePlayerExists indicates a player with the same name is already seated
+	CodeInvalidAction  ErrorCode = "invalid_action"   // CodeInvalidAction indicates the game rejected the bet or action
+	CodeRateLimited    ErrorCode = "rate_limited"     // CodeRateLimited indicates the player is acting too quickly
+	CodeUnauthorized   ErrorCode = "unauthorized"     // CodeUnauthorized indicates the seat token is missing or unknown
+	CodeForbidden      ErrorCode = "forbidden"        // CodeForbidden indicates the seat token belongs to another player
+)
+
+// Error is the JSON body returned for a failed request
+type Error struct {
+	Status  int       `json:"status"`  // Status is the HTTP status code
+	Code    ErrorCode `json:"code"`    // Code identifies the kind of error
+	Message string    `json:"message"` // Message describes the error
+}
+
+// Error returns the error message
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// newError creates an API error
+func newError(status int, code ErrorCode, format string, args ...any) *Error {
+	return &Error{Status: status, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// gameError converts an error returned by the game into an API error
+func gameError(err error) *Error {
+	var apiErr *Error
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr
+	case errors.Is(err, blackjack.ErrRateLimited):
+		return newError(stdhttp.StatusTooManyRequests, CodeRateLimited, "%s", err)
+	case errors.Is(err, ws.ErrUnauthorized):
+		return newError(stdhttp.StatusUnauthorized, CodeUnauthorized, "%s", err)
+	case errors.Is(err, ws.ErrForbidden):
+		return newError(stdhttp.StatusForbidden, CodeForbidden, "%s", err)
+	case errors.Is(err, ws.ErrSeated):
+		return newError(stdhttp.StatusConflict, CodePlayerExists, "%s", err)
+	default:
+		return newError(stdhttp.StatusConflict, CodeInvalidAction, "%s", err)
+	}
+}
@@ -0,0 +1,391 @@
+// Package http exposes blackjack games as a REST API.
+//
+// The API is organized around tables, each of which hosts a single game:
+//
+//	POST   /tables                        create a table
+//	GET    /tables                        list tables
+//	GET    /tables/{table}                fetch the table's state
+//	DELETE /tables/{table}                close a table
+//	POST   /tables/{table}/players         join the table
+//	DELETE /tables/{table}/players/{name}  leave the table
+//	POST   /tables/{table}/rounds          start a new round
+//	POST   /tables/{table}/bets            place a bet
+//	POST   /tables/{table}/deal            deal the initial cards
+//	POST   /tables/{table}/actions         hit, stand, double, split, or surrender
+//	GET    /tables/{table}/ws              stream the table's events over a WebSocket
+//
+// Joining a table issues a seat token, which must be given as a bearer token in the
+// Authorization header of every later request made at the table for the player:
+// leaving, starting a round, betting, dealing, and acting. A request without a
+// valid token fails with a 401, and a token used for another player with a 403.
+//
+// State is redacted so that the dealer's hole card is hidden until it is revealed.
+// Once every player has finished their hands the dealer plays and the round is
+// settled automatically. Bets and actions honor an Idempotency-Key header so that
+// clients may safely retry them. The WebSocket endpoint is served by package ws;
+// clients connecting with a seat token in the "token" query parameter may also play
+// over it.
+package http
+
+import (
+	"encoding/json"
+	stdhttp "net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/blackjack/server/ws"
+)
+
+const (
+	DefaultDecks = 6    // DefaultDecks is the number of decks used by a table when none is requested
+	DefaultChips = 1000 // DefaultChips is the bankroll given to a player who joins without requesting one
+)
+
+// Server serves blackjack tables over HTTP
+type Server struct {
+	mu      sync.RWMutex
+	tables  map[string]*ws.Table
+	options []blackjack.GameOption
+	mux     *stdhttp.ServeMux
+}
+
+// NewServer creates a server with no tables. The options are applied to every table created.
+func NewServer(options ...blackjack.GameOption) *Server {
+	s := &Server{
+		tables:  make(map[string]*ws.Table),
+		options: options,
+		mux:     stdhttp.NewServeMux(),
+	}
+	s.mux.HandleFunc("POST /tables", s.handle(s.createTable))
+	s.mux.HandleFunc("GET /tables", s.handle(s.listTables))
+	s.mux.HandleFunc("GET /tables/{table}", s.handle(s.getTable))
+	s.mux.HandleFunc("DELETE /tables/{table}", s.handle(s.deleteTable))
+	s.mux.HandleFunc("POST /tables/{table}/players", s.handle(s.join))
+	s.mux.HandleFunc("DELETE /tables/{table}/players/{name}", s.handleSeat(s.leave))
+	s.mux.HandleFunc("POST /tables/{table}/rounds", s.handleSeat(s.startRound))
+	s.mux.HandleFunc("POST /tables/{table}/bets", s.handleSeat(s.bet))
+	s.mux.HandleFunc("POST /tables/{table}/deal", s.handleSeat(s.deal))
+	s.mux.HandleFunc("POST /tables/{table}/actions", s.handleSeat(s.act))
+	s.mux.HandleFunc("GET /tables/{table}/ws", s.stream)
+	return s
+}
+
+// ServeHTTP serves an API request
+func (s *Server) ServeHTTP(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// AddTable hosts an existing game as a table, returning the table's ID
+func (s *Server) AddTable(game *blackjack.Game) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables[game.ID()] = ws.NewTable(game)
+	return game.ID()
+}
+
+// handlerFunc handles a request, returning the response body or an error
+type handlerFunc func(r *stdhttp.Request) (int, any, error)
+
+// seatHandlerFunc handles a request made for a seated player, with exclusive access
+// to the table's game
+type seatHandlerFunc func(r *stdhttp.Request, game *blackjack.Game, player string) (int, any, error)
+
+// handle adapts a handler function to write its response or error as JSON
+func (s *Server) handle(fn handlerFunc) stdhttp.HandlerFunc {
+	return func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		status, body, err := fn(r)
+		if err != nil {
+			apiErr := gameError(err)
+			if apiErr.Status == stdhttp.StatusUnauthorized {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+			}
+			writeJSON(w, apiErr.Status, apiErr)
+			return
+		}
+		writeJSON(w, status, body)
+	}
+}
+
+// handleSeat adapts a seat handler function, looking up the table and running the
+// handler for the player the request's seat token was issued to, with exclusive
+// access to the table's game. The table's state is broadcast to its WebSocket
+// clients if the handler succeeds.
+func (s *Server) handleSeat(fn seatHandlerFunc) stdhttp.HandlerFunc {
+	return s.handle(func(r *stdhttp.Request) (int, any, error) {
+		t, err := s.table(r.PathValue("table"))
+		if err != nil {
+			return 0, nil, err
+		}
+		var status int
+		var body any
+		err = t.DoAs(seatToken(r), func(game *blackjack.Game, player string) error {
+			var err error
+			status, body, err = fn(r, game, player)
+			return err
+		})
+		return status, body, err
+	})
+}
+
+// seatToken returns the seat token given as a bearer token in the request's
+// Authorization header, or an empty string if there is none
+func seatToken(r *stdhttp.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// table returns the table with the given ID
+func (s *Server) table(id string) (*ws.Table, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.tables[id]
+	if !ok {
+		return nil, newError(stdhttp.StatusNotFound, CodeTableNotFound, "table %s not found", id)
+	}
+	return t, nil
+}
+
+// writeJSON writes the body as JSON with the given status
+func writeJSON(w stdhttp.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body != nil {
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+// decode reads the JSON request body into v
+func decode(r *stdhttp.Request, v any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return newError(stdhttp.StatusBadRequest, CodeInvalidRequest, "invalid request body: %s", err)
+	}
+	return nil
+}
+
+// CreateTableRequest is the body of a request to create a table
+type CreateTableRequest struct {
+	Decks int              `json:"decks,omitempty"` // Decks is the number of decks in the shoe, or DefaultDecks if zero
+	Rules *blackjack.Rules `json:"rules,omitempty"` // Rules are the table rules, with any rule omitted taken from the default rules
+}
+
+// createTable creates a new table
+func (s *Server) createTable(r *stdhttp.Request) (int, any, error) {
+	rules := blackjack.DefaultRules()
+	req := CreateTableRequest{Rules: &rules}
+	if r.ContentLength != 0 {
+		if err := decode(r, &req); err != nil {
+			return 0, nil, err
+		}
+	}
+	if req.Rules != nil {
+		if err := req.Rules.Validate(); err != nil {
+			return 0, nil, newError(stdhttp.StatusBadRequest, CodeInvalidRequest, "invalid rules: %s", err)
+		}
+	}
+	if req.Decks < 0 {
+		return 0, nil, newError(stdhttp.StatusBadRequest, CodeInvalidRequest, "decks must not be negative")
+	}
+	if req.Decks == 0 {
+		req.Decks = DefaultDecks
+	}
+	options := append([]blackjack.GameOption{}, s.options...)
+	if req.Rules != nil {
+		options = append(options, blackjack.WithRules(*req.Rules))
+	}
+	game := blackjack.New(req.Decks, options...)
+	state := game.State()
+	s.AddTable(game)
+	return stdhttp.StatusCreated, state, nil
+}
+
+// listTables returns the IDs of every table
+func (s *Server) listTables(r *stdhttp.Request) (int, any, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.tables))
+	for id := range s.tables {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return stdhttp.StatusOK, ids, nil
+}
+
+// getTable returns the redacted state of a table
+func (s *Server) getTable(r *stdhttp.Request) (int, any, error) {
+	t, err := s.table(r.PathValue("table"))
+	if err != nil {
+		return 0, nil, err
+	}
+	var state blackjack.GameState
+	t.View(func(game *blackjack.Game) {
+		state = game.State()
+	})
+	return stdhttp.StatusOK, state, nil
+}
+
+// stream upgrades the request to a WebSocket connection that streams the table's events
+func (s *Server) stream(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	t, err := s.table(r.PathValue("table"))
+	if err != nil {
+		apiErr := gameError(err)
+		writeJSON(w, apiErr.Status, apiErr)
+		return
+	}
+	t.ServeHTTP(w, r)
+}
+
+// deleteTable closes a table, disconnecting its WebSocket clients
+func (s *Server) deleteTable(r *stdhttp.Request) (int, any, error) {
+	id := r.PathValue("table")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tables[id]
+	if !ok {
+		return 0, nil, newError(stdhttp.StatusNotFound, CodeTableNotFound, "table %s not found", id)
+	}
+	delete(s.tables, id)
+	t.Close()
+	return stdhttp.StatusNoContent, nil, nil
+}
+
+// JoinRequest is the body of a request to join a table
+type JoinRequest struct {
+	Name  string `json:"name"`            // Name is the player's name, which must be unique at the table
+	Chips int    `json:"chips,omitempty"` // Chips is the player's starting bankroll, or DefaultChips if zero
+}
+
+// JoinResponse is the result of joining a table
+type JoinResponse struct {
+	Token  string                `json:"token"`  // Token is the seat token that authorizes the player's later requests
+	Player blackjack.PlayerState `json:"player"` // Player is the seated player's state
+}
+
+// join seats a player at the table, issuing the player's seat token
+func (s *Server) join(r *stdhttp.Request) (int, any, error) {
+	t, err := s.table(r.PathValue("table"))
+	if err != nil {
+		return 0, nil, err
+	}
+	var req JoinRequest
+	if err := decode(r, &req); err != nil {
+		return 0, nil, err
+	}
+	if req.Name == "" {
+		return 0, nil, newError(stdhttp.StatusBadRequest, CodeInvalidRequest, "name is required")
+	}
+	if req.Chips < 0 {
+		return 0, nil, newError(stdhttp.StatusBadRequest, CodeInvalidRequest, "chips must not be negative")
+	}
+	if req.Chips == 0 {
+		req.Chips = DefaultChips
+	}
+	token, err := t.Join(req.Name, req.Chips)
+	if err != nil {
+		return 0, nil, err
+	}
+	resp := JoinResponse{Token: token}
+	t.View(func(game *blackjack.Game) {
+		if player := game.GetPlayer(req.Name); player != nil {
+			resp.Player = player.State()
+		}
+	})
+	return stdhttp.StatusCreated, resp, nil
+}
+
+// leave removes the player from the table
+func (s *Server) leave(r *stdhttp.Request, game *blackjack.Game, player string) (int, any, error) {
+	if name := r.PathValue("name"); name != player {
+		return 0, nil, ws.ErrForbidden
+	}
+	if game.GetPlayer(player) == nil {
+		return 0, nil, newError(stdhttp.StatusNotFound, CodePlayerNotFound, "player %s not found", player)
+	}
+	if err := game.RemovePlayer(player); err != nil {
+		return 0, nil, err
+	}
+	return stdhttp.StatusNoContent, nil, nil
+}
+
+// startRound starts a new round
+func (s *Server) startRound(r *stdhttp.Request, game *blackjack.Game, player string) (int, any, error) {
+	if err := game.StartNewRound(); err != nil {
+		return 0, nil, err
+	}
+	return stdhttp.StatusOK, game.State(), nil
+}
+
+// BetRequest is the body of a request to place a bet
+type BetRequest struct {
+	Player string `json:"player"`         // Player is the name of the player betting, who must hold the request's seat token
+	Spot   int    `json:"spot,omitempty"` // Spot is the player's spot to bet on, starting at 0
+	Amount int    `json:"amount"`         // Amount is the bet
+}
+
+// bet places a bet on one of the player's spots
+func (s *Server) bet(r *stdhttp.Request, game *blackjack.Game, player string) (int, any, error) {
+	var req BetRequest
+	if err := decode(r, &req); err != nil {
+		return 0, nil, err
+	}
+	if req.Player != player {
+		return 0, nil, ws.ErrForbidden
+	}
+	if err := game.PlaceBetWithKeyContext(r.Context(), r.Header.Get("Idempotency-Key"), req.Player, req.Spot, req.Amount); err != nil {
+		return 0, nil, err
+	}
+	return stdhttp.StatusOK, game.State(), nil
+}
+
+// deal deals the initial cards once bets have been placed
+func (s *Server) deal(r *stdhttp.Request, game *blackjack.Game, player string) (int, any, error) {
+	if err := game.DealInitialCards(); err != nil {
+		return 0, nil, err
+	}
+	if _, err := game.SettleIfFinished(); err != nil {
+		return 0, nil, err
+	}
+	return stdhttp.StatusOK, game.State(), nil
+}
+
+// ActionRequest is the body of a request to act on a player's current hand
+type ActionRequest struct {
+	Player string               `json:"player"` // Player is the name of the player acting, who must hold the request's seat token
+	Action blackjack.ActionType `json:"action"` // Action is hit, stand, double, split, or surrender
+}
+
+// ActionResponse is the result of a player's action
+type ActionResponse struct {
+	Outcome blackjack.ActionOutcome `json:"outcome"` // Outcome describes what the action did
+	State   blackjack.GameState     `json:"state"`   // State is the table's state after the action
+}
+
+// act performs an action on the player's current hand
+func (s *Server) act(r *stdhttp.Request, game *blackjack.Game, player string) (int, any, error) {
+	var req ActionRequest
+	if err := decode(r, &req); err != nil {
+		return 0, nil, err
+	}
+	if req.Player != player {
+		return 0, nil, ws.ErrForbidden
+	}
+	switch req.Action {
+	case blackjack.ActionHit, blackjack.ActionStand, blackjack.ActionDouble, blackjack.ActionSplit, blackjack.ActionSurrender:
+	default:
+		return 0, nil, newError(stdhttp.StatusBadRequest, CodeInvalidRequest, "unknown action %q", req.Action)
+	}
+	outcome, err := game.ActWithKeyContext(r.Context(), r.Header.Get("Idempotency-Key"), req.Player, req.Action)
+	if err != nil {
+		return 0, nil, err
+	}
+	if _, err := game.SettleIfFinished(); err != nil {
+		return 0, nil, err
+	}
+	return stdhttp.StatusOK, ActionResponse{Outcome: outcome, State: game.State()}, nil
+}
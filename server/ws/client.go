@@ -0,0 +1,83 @@
+package ws
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// client is a connection to a table
+type client struct {
+	table   *Table
+	conn    *websocket.Conn
+	player  string       // player is the name of the client's player, or empty for a spectator
+	token   string       // token is the seat token of the client's player, or empty if the client is not acting for a seat
+	send    chan Message // send queues messages to be written to the client
+	dropped bool         // dropped is true once the client has fallen too far behind
+}
+
+// queue queues a message for the client without blocking. A client that is too
+// slow to keep up is disconnected rather than stalling the table. It must be
+// called with the table's lock held.
+func (c *client) queue(msg Message) {
+	if c.dropped {
+		return
+	}
+	select {
+	case c.send <- msg:
+	default:
+		c.dropped = true
+		slog.Debug("dropping slow websocket client", "player", c.player)
+		c.conn.Close()
+	}
+}
+
+// readLoop reads commands from the client until the connection is closed
+func (c *client) readLoop() {
+	defer func() {
+		c.table.remove(c)
+		c.conn.Close()
+	}()
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		var cmd Command
+		if err := c.conn.ReadJSON(&cmd); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				slog.Debug("websocket read failed", "player", c.player, "error", err)
+			}
+			return
+		}
+		c.table.handle(c, cmd)
+	}
+}
+
+// writeLoop writes queued messages and periodic pings to the client
+func (c *client) writeLoop() {
+	ticker := time.NewTicker(pingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+	for {
+		select {
+		case msg, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package ws
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rbrabson/blackjack"
+)
+
+// MessageType identifies the kind of message sent to a client
+type MessageType string
+
+const (
+	MessageEvent   MessageType = "event"   // MessageEvent carries an event emitted by the game
+	MessageState   MessageType = "state"   // MessageState carries the table's redacted state
+	MessageOutcome MessageType = "outcome" // MessageOutcome carries the outcome of the client's action
+	MessageError   MessageType = "error"   // MessageError reports that the client's command failed
+	MessageJoined  MessageType = "joined"  // MessageJoined carries the seat token issued when the client's player joins
+)
+
+// Message is sent from the table to a client
+type Message struct {
+	Type    MessageType              `json:"type"`
+	Command CommandType              `json:"command,omitempty"` // Command is the command an outcome or error is for
+	Event   *blackjack.Event         `json:"event,omitempty"`
+	State   *blackjack.GameState     `json:"state,omitempty"`
+	Outcome *blackjack.ActionOutcome `json:"outcome,omitempty"`
+	Error   string                   `json:"error,omitempty"`
+	Token   string                   `json:"token,omitempty"` // Token is the seat token issued to the client's player
+}
+
+// CommandType identifies a command sent by a client
+type CommandType string
+
+const (
+	CommandJoin   CommandType = "join"   // CommandJoin seats the client's player at the table
+	CommandLeave  CommandType = "leave"  // CommandLeave removes the client's player from the table
+	CommandStart  CommandType = "start"  // CommandStart starts a new round
+	CommandBet    CommandType = "bet"    // CommandBet places a bet on one of the player's spots
+	CommandDeal   CommandType = "deal"   // CommandDeal deals the initial cards once bets are placed
+	CommandAction CommandType = "action" // CommandAction hits, stands, doubles, splits, or surrenders
+)
+
+// DefaultChips is the bankroll given to a player who joins without requesting one
+const DefaultChips = 1000
+
+// Command is sent from a client to the table
+type Command struct {
+	Type   CommandType          `json:"type"`
+	Chips  int                  `json:"chips,omitempty"`  // Chips is the bankroll to join with, or DefaultChips if zero
+	Spot   int                  `json:"spot,omitempty"`   // Spot is the spot to bet on, starting at 0
+	Amount int                  `json:"amount,omitempty"` // Amount is the bet
+	Action blackjack.ActionType `json:"action,omitempty"` // Action is the action to take on the player's current hand
+	Key    string               `json:"key,omitempty"`    // Key is an optional idempotency key for bets and actions
+}
+
+// errSpectator is returned when a spectator sends a command, as every command
+// requires a player
+var errSpectator = errors.New("spectators may not send commands; connect with a player name")
+
+// execute runs a command for the client's player, returning the outcome of any
+// action. Every command but join requires the client to be acting for a seat.
+func (t *Table) execute(c *client, cmd Command) (*blackjack.ActionOutcome, error) {
+	game := t.game
+	player := c.player
+	switch {
+	case player == "":
+		return nil, errSpectator
+	case cmd.Type == CommandJoin:
+		if c.token != "" {
+			return nil, fmt.Errorf("player %s is %w", player, ErrSeated)
+		}
+		token, err := t.join(player, cmd.Chips)
+		if err != nil {
+			return nil, err
+		}
+		c.token = token
+		t.seats[player] = c
+		c.queue(Message{Type: MessageJoined, Command: cmd.Type, Token: token})
+		return nil, nil
+	case c.token == "" || t.tokens[c.token] != player:
+		return nil, fmt.Errorf("player %s must join the table, or connect with the seat's token: %w", player, ErrUnauthorized)
+	}
+	switch cmd.Type {
+	case CommandStart:
+		return nil, game.StartNewRound()
+	case CommandDeal:
+		if err := game.DealInitialCards(); err != nil {
+			return nil, err
+		}
+		_, err := game.SettleIfFinished()
+		return nil, err
+	case CommandLeave:
+		return nil, game.RemovePlayer(player)
+	case CommandBet:
+		return nil, game.PlaceBetWithKey(cmd.Key, player, cmd.Spot, cmd.Amount)
+	case CommandAction:
+		outcome, err := game.ActWithKey(cmd.Key, player, cmd.Action)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := game.SettleIfFinished(); err != nil {
+			return nil, err
+		}
+		return &outcome, nil
+	default:
+		return nil, fmt.Errorf("unknown command %q", cmd.Type)
+	}
+}
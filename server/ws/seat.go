@@ -0,0 +1,129 @@
+package ws
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/rbrabson/blackjack"
+)
+
+// ErrUnauthorized is returned when a seat token is missing or was not issued by the table
+var ErrUnauthorized = errors.New("a valid seat token is required")
+
+// ErrForbidden is returned when a seat token is used to act for another player
+var ErrForbidden = errors.New("the seat token belongs to another player")
+
+// ErrSeated is returned when a player joins under the name of a seated player
+var ErrSeated = errors.New("already seated")
+
+// ErrConnected is returned when a second connection is made for a seat
+var ErrConnected = errors.New("already connected")
+
+// Join seats a player at the table with the given bankroll (DefaultChips if zero),
+// returning the seat token that authorizes the player's commands. The token is
+// given to DoAs, or in the "token" query parameter when connecting over WebSockets.
+func (t *Table) Join(name string, chips int) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	token, err := t.join(name, chips)
+	if err != nil {
+		return "", err
+	}
+	t.broadcastState()
+	return token, nil
+}
+
+// join seats a player and issues their seat token. It must be called with the
+// table's lock held.
+func (t *Table) join(name string, chips int) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("a player name is required")
+	}
+	if t.game.GetPlayer(name) != nil {
+		return "", fmt.Errorf("player %s is %w", name, ErrSeated)
+	}
+	if chips < 0 {
+		return "", fmt.Errorf("chips must not be negative")
+	}
+	if chips == 0 {
+		chips = DefaultChips
+	}
+	t.game.AddPlayer(name, blackjack.WithChips(chips))
+	token := rand.Text()
+	t.tokens[token] = name
+	return token, nil
+}
+
+// DoAs runs fn with exclusive access to the game on behalf of the player the seat
+// token was issued to. If fn succeeds, the table's state is broadcast to every client.
+func (t *Table) DoAs(token string, fn func(game *blackjack.Game, player string) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	player, err := t.authorize(token)
+	if err != nil {
+		return err
+	}
+	err = fn(t.game, player)
+	t.releaseSeats()
+	if err != nil {
+		return err
+	}
+	t.broadcastState()
+	return nil
+}
+
+// authorize returns the seated player the token was issued to. It must be called
+// with the table's lock held.
+func (t *Table) authorize(token string) (string, error) {
+	player, ok := t.tokens[token]
+	if token == "" || !ok {
+		return "", ErrUnauthorized
+	}
+	return player, nil
+}
+
+// releaseSeats revokes the tokens of players who are no longer seated and unbinds
+// their connections, so that a new player may take the name. It must be called
+// with the table's lock held.
+func (t *Table) releaseSeats() {
+	for token, player := range t.tokens {
+		if t.game.GetPlayer(player) != nil {
+			continue
+		}
+		delete(t.tokens, token)
+		if c, ok := t.seats[player]; ok && c.token == token {
+			c.token = ""
+			delete(t.seats, player)
+		}
+	}
+}
+
+// bind binds the client to the seat its token was issued to, so that no other
+// connection may act for the seat. A client without a token may watch, or join
+// under its player name if the name is not seated. It must be called with the
+// table's lock held.
+func (t *Table) bind(c *client, token string) error {
+	if token == "" {
+		if c.player != "" && t.game.GetPlayer(c.player) != nil {
+			return ErrUnauthorized
+		}
+		return nil
+	}
+	player, err := t.authorize(token)
+	if err != nil {
+		return err
+	}
+	if c.player == "" {
+		c.player = player
+	}
+	if player != c.player {
+		return ErrForbidden
+	}
+	if _, ok := t.seats[player]; ok {
+		return fmt.Errorf("player %s is %w", player, ErrConnected)
+	}
+	c.token = token
+	t.seats[player] = c
+	return nil
+}
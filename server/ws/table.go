@@ -0,0 +1,208 @@
+// Package ws hosts blackjack games for clients connected over WebSockets.
+//
+// Each connected client receives every event emitted by the game as it happens
+// (cards dealt, turns ending, chips changing, rounds settling) followed by the
+// table's redacted state after each command. A client that connects with a player
+// name may join the table, and is sent the seat token that authorizes the player's
+// commands; a client that connects with a seat's token, given by the "token" query
+// parameter, acts for that seat. Only one connection may act for a seat at a time.
+// Clients that connect without a name or token are spectators, who may watch but
+// not send commands.
+package ws
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rbrabson/blackjack"
+)
+
+const (
+	writeWait    = 10 * time.Second    // writeWait is the time allowed to write a message to a client
+	pongWait     = 60 * time.Second    // pongWait is the time allowed to read the next pong from a client
+	pingInterval = (pongWait * 9) / 10 // pingInterval is how often clients are pinged
+	sendBuffer   = 256                 // sendBuffer is the number of messages queued for a client before it is dropped
+)
+
+// Table hosts a game for clients connected over WebSockets. Table serves the
+// WebSocket endpoint; the player's name is given by the "player" query parameter,
+// and the seat token of a seated player by the "token" query parameter.
+type Table struct {
+	mu       sync.Mutex
+	game     *blackjack.Game
+	clients  map[*client]struct{}
+	tokens   map[string]string  // tokens are the names of the seated players, by their seat tokens
+	seats    map[string]*client // seats are the clients connected for seated players, by name
+	upgrader websocket.Upgrader
+	closed   bool // closed is true once the table has been closed and accepts no more clients
+}
+
+// NewTable creates a table that hosts the game. The table registers itself as a
+// listener on the game so that the game's events are streamed to clients. The
+// game must only be used through the table once it is hosted.
+func NewTable(game *blackjack.Game) *Table {
+	t := &Table{
+		game:    game,
+		clients: make(map[*client]struct{}),
+		tokens:  make(map[string]string),
+		seats:   make(map[string]*client),
+	}
+	game.AddListener(t)
+	return t
+}
+
+// SetCheckOrigin sets the function used to accept or reject cross-origin
+// connections. By default only same-origin connections are accepted.
+func (t *Table) SetCheckOrigin(check func(r *http.Request) bool) {
+	t.upgrader.CheckOrigin = check
+}
+
+// Game returns the hosted game
+func (t *Table) Game() *blackjack.Game {
+	return t.game
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and serves the client.
+// A connection for a seated player is refused unless it has the seat's token and
+// no other connection is acting for the seat.
+func (t *Table) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c := &client{
+		table:  t,
+		player: r.URL.Query().Get("player"),
+		send:   make(chan Message, sendBuffer),
+	}
+	t.mu.Lock()
+	err := t.bind(c, r.URL.Query().Get("token"))
+	t.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), bindStatus(err))
+		return
+	}
+
+	conn, err := t.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Debug("failed to upgrade websocket connection", "error", err)
+		t.remove(c)
+		return
+	}
+	c.conn = conn
+
+	t.mu.Lock()
+	if t.closed {
+		t.unbind(c)
+		t.mu.Unlock()
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "table closed"), time.Now().Add(writeWait))
+		conn.Close()
+		return
+	}
+	t.clients[c] = struct{}{}
+	state := t.game.State()
+	c.queue(Message{Type: MessageState, State: &state})
+	t.mu.Unlock()
+
+	go c.writeLoop()
+	c.readLoop()
+}
+
+// OnEvent broadcasts a game event to every connected client. Events are emitted
+// while the table's lock is held by the command that caused them.
+func (t *Table) OnEvent(event blackjack.Event) {
+	for c := range t.clients {
+		c.queue(Message{Type: MessageEvent, Event: &event})
+	}
+}
+
+// Do runs fn with exclusive access to the game. If fn succeeds, the table's state
+// is broadcast to every client. Do allows the game to be driven by other means,
+// such as an HTTP API, while clients are connected.
+func (t *Table) Do(fn func(game *blackjack.Game) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	err := fn(t.game)
+	t.releaseSeats()
+	if err != nil {
+		return err
+	}
+	t.broadcastState()
+	return nil
+}
+
+// View runs fn with exclusive access to the game without broadcasting the table's state
+func (t *Table) View(fn func(game *blackjack.Game)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn(t.game)
+}
+
+// broadcastState sends the table's state to every client. It must be called with
+// the table's lock held.
+func (t *Table) broadcastState() {
+	state := t.game.State()
+	for c := range t.clients {
+		c.queue(Message{Type: MessageState, State: &state})
+	}
+}
+
+// handle runs a client's command against the game and broadcasts the resulting state
+func (t *Table) handle(c *client, cmd Command) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	outcome, err := t.execute(c, cmd)
+	t.releaseSeats()
+	if err != nil {
+		c.queue(Message{Type: MessageError, Error: err.Error(), Command: cmd.Type})
+		return
+	}
+	if outcome != nil {
+		c.queue(Message{Type: MessageOutcome, Outcome: outcome, Command: cmd.Type})
+	}
+	t.broadcastState()
+}
+
+// Close disconnects every client and stops the table from accepting new ones
+func (t *Table) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+	clear(t.seats)
+	for c := range t.clients {
+		delete(t.clients, c)
+		close(c.send)
+	}
+}
+
+// remove disconnects a client from the table
+func (t *Table) remove(c *client) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.unbind(c)
+	if _, ok := t.clients[c]; ok {
+		delete(t.clients, c)
+		close(c.send)
+	}
+}
+
+// unbind frees the seat the client was acting for. It must be called with the
+// table's lock held.
+func (t *Table) unbind(c *client) {
+	if t.seats[c.player] == c {
+		delete(t.seats, c.player)
+	}
+}
+
+// bindStatus returns the HTTP status for a connection refused by bind
+func bindStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusConflict
+	}
+}
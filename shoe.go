@@ -2,13 +2,25 @@ package blackjack
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"slices"
 
 	"github.com/rbrabson/cards"
 )
 
 const (
-	CutCardPenetration = 0.75 // CutCardPenetration is the fraction of the shoe dealt before reshuffling
-	NumCardsInDeck     = 52   // NumCardsInDeck is the number of cards in a standard deck
+	CutCardPenetration  = 0.75 // CutCardPenetration is the fraction of the shoe dealt before reshuffling
+	NumCardsInDeck      = 52   // NumCardsInDeck is the number of cards in a standard deck
+	CardsPerHandReserve = 6    // CardsPerHandReserve is the number of cards kept for each hand, including the dealer's, when the shoe is finished
+)
+
+// ShufflePolicy decides when a game reshuffles its shoe. The shoe is only ever
+// reshuffled between rounds, unless it runs out of cards in the middle of one.
+type ShufflePolicy int
+
+const (
+	ShuffleAtCutCard  ShufflePolicy = iota // ShuffleAtCutCard reshuffles before the next round once the cut card has come out
+	ShuffleFinishShoe                      // ShuffleFinishShoe ignores the cut card, dealing the shoe down until too few cards are left to finish a round
 )
 
 // Shoe wraps the cards.Shoe with blackjack-specific functionality
@@ -16,6 +28,10 @@ type Shoe struct {
 	cards    cards.Shoe // shoe is the set of cards to be dealt
 	numDecks int        // numDecdks is the number of decks in the shoe
 	cutCard  int        // Position where cut card is placed (reshuffle point)
+	rng      *rand.Rand // rng shuffles the shoe, if seeded (nil for the global source)
+	pcg      *rand.PCG  // pcg is the source of rng, kept so its state can be saved
+
+	penetration float64 // penetration is the fraction of the shoe dealt before reshuffling (0 for CutCardPenetration)
 }
 
 // NewShoe creates a new blackjack shoe with the specified number of decks
@@ -28,6 +44,22 @@ func NewShoe(numDecks int) *Shoe {
 	return s
 }
 
+// NewShoeFromCards creates a shoe that deals the given cards in order. Once the
+// cards are exhausted the shoe is reshuffled as a regular shoe. This is primarily
+// used to reproduce a recorded round.
+func NewShoeFromCards(stacked []cards.Card) *Shoe {
+	numDecks := max(1, (len(stacked)+NumCardsInDeck-1)/NumCardsInDeck)
+	s := &Shoe{
+		cards:    make(cards.Shoe, len(stacked)),
+		numDecks: numDecks,
+	}
+	copy(s.cards, stacked)
+	// Place the cut card at the end so the stacked cards are never reshuffled early
+	s.cutCard = numDecks * NumCardsInDeck
+
+	return s
+}
+
 // Draw deals a card from the shoe
 func (s *Shoe) Draw() (cards.Card, error) {
 	if s.IsEmpty() {
@@ -55,10 +87,53 @@ func (s *Shoe) CardsRemaining() int {
 // Reshuffle creates a new shuffled shoe with the same number of decks
 func (s *Shoe) Reshuffle() {
 	s.cards = cards.NewShoe(s.numDecks)
-	s.cards.Shuffle()
+	if s.rng != nil {
+		s.rng.Shuffle(len(s.cards), func(i, j int) {
+			s.cards[i], s.cards[j] = s.cards[j], s.cards[i]
+		})
+	} else {
+		s.cards.Shuffle()
+	}
 
 	// Reset cut card position
-	s.cutCard = int(float64(len(s.cards)) * CutCardPenetration)
+	s.cutCard = int(float64(len(s.cards)) * s.CutPenetration())
+}
+
+// Cut moves the given number of cards from the top of the shoe to the bottom, as
+// when a player cuts a freshly shuffled shoe. The position must leave at least one
+// card on either side of the cut.
+func (s *Shoe) Cut(position int) error {
+	if position < 1 || position >= len(s.cards) {
+		return fmt.Errorf("cut must be between 1 and %d cards", len(s.cards)-1)
+	}
+	s.cards = slices.Concat(s.cards[position:], s.cards[:position])
+	return nil
+}
+
+// CutPenetration returns the fraction of the shoe dealt before it is reshuffled
+func (s *Shoe) CutPenetration() float64 {
+	if s.penetration <= 0 || s.penetration > 1 {
+		return CutCardPenetration
+	}
+	return s.penetration
+}
+
+// SetPenetration places the cut card so that the given fraction of the shoe is
+// dealt before it is reshuffled, such as 0.75 to deal three quarters of the shoe.
+// A fraction outside (0, 1] places the cut card at CutCardPenetration.
+func (s *Shoe) SetPenetration(penetration float64) {
+	s.penetration = penetration
+	s.cutCard = int(float64(s.numDecks*NumCardsInDeck) * s.CutPenetration())
+}
+
+// RankCounts returns how many cards of each rank are left in the shoe, without
+// revealing the order they will be dealt in
+func (s *Shoe) RankCounts() map[cards.Rank]int {
+	counts := make(map[cards.Rank]int, len(cards.Ranks))
+	for _, card := range s.cards {
+		counts[card.Rank]++
+	}
+	return counts
 }
 
 // NumDecks returns the number of decks in the shoe
@@ -78,3 +153,60 @@ func (s *Shoe) String() string {
 	return fmt.Sprintf("Shoe: %d decks, %d cards remaining (%.1f%% penetration)",
 		s.numDecks, len(s.cards), s.Penetration())
 }
+
+// Seed makes every shuffle of the shoe deterministic, starting from the seed, and
+// reshuffles the shoe. Two shoes with the same number of decks and seed deal the
+// same cards.
+func (s *Shoe) Seed(seed uint64) {
+	s.seed(seed)
+	s.Reshuffle()
+}
+
+// seed makes every later shuffle of the shoe deterministic, starting from the seed,
+// leaving the cards in the shoe as they are
+func (s *Shoe) seed(seed uint64) {
+	s.setSource(rand.NewPCG(seed, seed))
+}
+
+// setSource shuffles the shoe from the given source
+func (s *Shoe) setSource(pcg *rand.PCG) {
+	s.pcg = pcg
+	s.rng = rand.New(pcg)
+}
+
+// WithPenetration sets the fraction of the game's shoe dealt before it is
+// reshuffled
+func WithPenetration(penetration float64) GameOption {
+	return func(g *Game) {
+		g.shoe.SetPenetration(penetration)
+	}
+}
+
+// WithShufflePolicy sets when the game reshuffles its shoe
+func WithShufflePolicy(policy ShufflePolicy) GameOption {
+	return func(g *Game) {
+		g.shufflePolicy = policy
+	}
+}
+
+// shuffleDue returns whether the shoe should be reshuffled before the next round
+// under the game's shuffle policy, and why
+func (bg *Game) shuffleDue() (bool, string) {
+	if bg.shufflePolicy == ShuffleFinishShoe {
+		hands := 1 // the dealer's
+		for _, player := range bg.players {
+			hands += player.spots
+		}
+		return bg.shoe.CardsRemaining() < hands*CardsPerHandReserve, "too few cards are left to finish a round"
+	}
+	return bg.shoe.NeedsReshuffle(), "the cut card was reached"
+}
+
+// WithSeed seeds the game's shoe so that the cards dealt are reproducible. A new
+// game's shoe is shuffled from the seed; a loaded game keeps the cards left in its
+// shoe and uses the seed, in place of the saved shuffle state, from its next shuffle.
+func WithSeed(seed uint64) GameOption {
+	return func(g *Game) {
+		g.shoe.seed(seed)
+	}
+}
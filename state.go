@@ -0,0 +1,194 @@
+package blackjack
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/rbrabson/cards"
+)
+
+// GameState is a structured snapshot of a game suitable for display or serialization.
+// The dealer's hole card is masked until it has been revealed.
+type GameState struct {
+	GameID         string        `json:"game_id"`
+	Round          int           `json:"round"`
+	RoundID        int64         `json:"round_id"`
+	Phase          Phase         `json:"phase"`
+	CardsRemaining int           `json:"cards_remaining"`
+	ShuffleNext    bool          `json:"shuffle_next"` // ShuffleNext is whether the shoe is shuffled before the next round, such as because the cut card has come out
+	Currency       Currency      `json:"currency"`     // Currency is the unit that chip amounts are counted in
+	Dealer         DealerState   `json:"dealer"`
+	Players        []PlayerState `json:"players"`
+}
+
+// DealerState is a snapshot of the dealer's hand
+type DealerState struct {
+	Cards            []*cards.Card `json:"cards"` // Cards are the dealer's cards, with a nil entry for a hidden hole card
+	Value            int           `json:"value"` // Value is the value of the visible cards
+	HoleCardRevealed bool          `json:"hole_card_revealed"`
+	Actions          []Action      `json:"actions"` // Actions are the dealer's actions, with the hole card omitted until revealed
+}
+
+// PlayerState is a snapshot of a player and their hands
+type PlayerState struct {
+	Name        string            `json:"name"`
+	Chips       int               `json:"chips"`
+	Active      bool              `json:"active"`
+	Waiting     bool              `json:"waiting"`
+	CurrentHand int               `json:"current_hand"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Hands       []HandState       `json:"hands"`
+}
+
+// HandState is a snapshot of a player's hand
+type HandState struct {
+	ID          int               `json:"id"`               // ID is the hand's number among the player's hands, starting at 1
+	ParentID    int               `json:"parent,omitempty"` // ParentID is the ID of the hand this hand was split from, or 0
+	Cards       []cards.Card      `json:"cards"`
+	Value       int               `json:"value"`
+	Soft        bool              `json:"soft"`
+	Bet         int               `json:"bet"`
+	Bets        BetComponents     `json:"bets"` // Bets are the wagers that make up the hand's bet
+	Winnings    int               `json:"winnings"`
+	Breakdown   WinningsBreakdown `json:"breakdown"` // Breakdown is the winnings broken down by the wager they came from
+	Spot        int               `json:"spot"`
+	Active      bool              `json:"active"`
+	Split       bool              `json:"split"`
+	Stood       bool              `json:"stood"`
+	Surrendered bool              `json:"surrendered"`
+	Busted      bool              `json:"busted"`
+	Blackjack   bool              `json:"blackjack"`
+	Result      GameResult        `json:"result,omitempty"` // Result is the outcome of the hand, once it has been settled
+	Actions     []Action          `json:"actions"`
+}
+
+// State returns a snapshot of the game. The dealer's hole card is hidden unless
+// it has been revealed.
+func (bg *Game) State() GameState {
+	state := GameState{
+		GameID:         bg.id,
+		Round:          bg.round,
+		RoundID:        bg.roundID,
+		Phase:          bg.phase,
+		CardsRemaining: bg.shoe.CardsRemaining(),
+		Currency:       bg.currency,
+		Dealer:         bg.dealer.State(),
+		Players:        make([]PlayerState, 0, len(bg.players)),
+	}
+	state.ShuffleNext, _ = bg.shuffleDue()
+	for _, player := range bg.players {
+		state.Players = append(state.Players, player.State())
+	}
+	return state
+}
+
+// State returns a snapshot of the dealer's hand, hiding the hole card unless it
+// has been revealed
+func (d *Dealer) State() DealerState {
+	state := DealerState{
+		Cards:            make([]*cards.Card, 0, d.hand.Count()),
+		HoleCardRevealed: d.holeCardRevealed,
+	}
+	for i, card := range d.hand.Cards() {
+		if i == 1 && !d.holeCardRevealed {
+			state.Cards = append(state.Cards, nil)
+			continue
+		}
+		state.Cards = append(state.Cards, &card)
+	}
+	state.Value = d.VisibleValue()
+
+	state.Actions = d.hand.Actions()
+	if !d.holeCardRevealed {
+		hideHoleCard(state.Actions)
+	}
+
+	return state
+}
+
+// hideHoleCard clears the card and hand value from the dealer's second deal, the
+// hole card, in the dealer's actions
+func hideHoleCard(actions []Action) {
+	deals := 0
+	for i, action := range actions {
+		if action.Type != ActionDeal {
+			continue
+		}
+		deals++
+		if deals == 2 {
+			actions[i].Card = nil
+			actions[i].Value = 0
+			return
+		}
+	}
+}
+
+// State returns a snapshot of the player and their hands
+func (p *Player) State() PlayerState {
+	state := PlayerState{
+		Name:        p.name,
+		Chips:       p.Chips(),
+		Active:      p.active,
+		Waiting:     p.waiting,
+		CurrentHand: p.currentHandIdx,
+		Metadata:    p.AllMetadata(),
+		Hands:       make([]HandState, 0, len(p.hands)),
+	}
+	for _, hand := range p.hands {
+		state.Hands = append(state.Hands, hand.State())
+	}
+	return state
+}
+
+// State returns a snapshot of the hand. The dealer's hand is shown without the
+// hole card unless it has been revealed.
+func (h *Hand) State() HandState {
+	if h.dealer != nil && !h.dealer.holeCardRevealed {
+		visible := *h
+		visible.dealer = nil
+		visible.cards = h.Cards()
+		if len(visible.cards) >= 2 {
+			visible.cards = slices.Delete(visible.cards, 1, 2)
+		}
+		visible.actions = h.Actions()
+		hideHoleCard(visible.actions)
+		return visible.State()
+	}
+
+	return HandState{
+		ID:          h.id,
+		ParentID:    h.ParentID(),
+		Cards:       h.Cards(),
+		Value:       h.Value(),
+		Soft:        h.IsSoft(),
+		Bet:         h.Bet(),
+		Bets:        h.bets.clone(),
+		Winnings:    h.winnings,
+		Breakdown:   h.WinningsBreakdown(),
+		Spot:        h.spot,
+		Active:      h.isActive,
+		Split:       h.isSplit,
+		Stood:       h.isStood,
+		Surrendered: h.isSurrendered,
+		Busted:      h.IsBusted(),
+		Blackjack:   h.IsBlackjack(),
+		Result:      h.result,
+		Actions:     h.Actions(),
+	}
+}
+
+// MarshalJSON returns the JSON representation of the hand's state
+func (h *Hand) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.State())
+}
+
+// MarshalJSON returns the JSON representation of the player's state
+func (p *Player) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.State())
+}
+
+// MarshalJSON returns the JSON representation of the dealer's state. The hole card
+// is omitted until it has been revealed.
+func (d *Dealer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.State())
+}
@@ -0,0 +1,103 @@
+package blackjack
+
+// PlayerStats summarizes a player's results across the rounds they have played
+type PlayerStats struct {
+	Name         string `json:"name"`          // Name is the player's name
+	Rounds       int    `json:"rounds"`        // Rounds is the number of rounds the player was dealt into
+	Hands        int    `json:"hands"`         // Hands is the number of hands played, including split hands
+	Wins         int    `json:"wins"`          // Wins is the number of hands won, including blackjacks and Charlies
+	Losses       int    `json:"losses"`        // Losses is the number of hands lost, including busts but not surrenders
+	Pushes       int    `json:"pushes"`        // Pushes is the number of hands that tied the dealer
+	Blackjacks   int    `json:"blackjacks"`    // Blackjacks is the number of natural blackjacks
+	Charlies     int    `json:"charlies"`      // Charlies is the number of hands won on a Charlie
+	Busts        int    `json:"busts"`         // Busts is the number of hands that went over 21
+	Surrenders   int    `json:"surrenders"`    // Surrenders is the number of hands surrendered
+	DoublesWon   int    `json:"doubles_won"`   // DoublesWon is the number of doubled hands that won
+	SplitsWon    int    `json:"splits_won"`    // SplitsWon is the number of split hands that won
+	TotalWagered int    `json:"total_wagered"` // TotalWagered is the total amount bet, including doubles and splits
+	Net          int    `json:"net"`           // Net is the net amount won (negative for a loss)
+	BiggestWin   int    `json:"biggest_win"`   // BiggestWin is the most won in a single round
+}
+
+// Stats returns the player's results in the rounds they have played since joining
+// the game or since their statistics were last reset
+func (p *Player) Stats() PlayerStats {
+	stats := p.stats
+	stats.Name = p.name
+	return stats
+}
+
+// ResetStats clears the player's statistics
+func (p *Player) ResetStats() {
+	p.stats = PlayerStats{}
+}
+
+// AddRound adds the results of a player's seat in a completed round to the statistics
+func (s *PlayerStats) AddRound(seat SeatRecord) {
+	s.Rounds++
+	for _, hand := range seat.Hands {
+		s.addHand(hand)
+	}
+	s.BiggestWin = max(s.BiggestWin, seat.Net())
+}
+
+// addHand adds the results of a single settled hand to the statistics
+func (s *PlayerStats) addHand(hand HandRecord) {
+	s.Hands++
+	s.TotalWagered += hand.Bet
+	s.Net += hand.Winnings
+
+	var doubled, split bool
+	for _, action := range hand.Actions {
+		switch action.Type {
+		case ActionBust:
+			s.Busts++
+		case ActionSurrender:
+			s.Surrenders++
+		case ActionDouble:
+			doubled = true
+		case ActionSplit:
+			split = true
+		}
+	}
+
+	switch hand.Result {
+	case PlayerWin, PlayerBlackjack, PlayerCharlie:
+		s.Wins++
+		switch hand.Result {
+		case PlayerBlackjack:
+			s.Blackjacks++
+		case PlayerCharlie:
+			s.Charlies++
+		}
+		if doubled {
+			s.DoublesWon++
+		}
+		if split {
+			s.SplitsWon++
+		}
+	case DealerWin, DealerBlackjack:
+		s.Losses++
+	case Push:
+		s.Pushes++
+	}
+}
+
+// ChipHistory returns the named player's chips before the first round in the game's
+// history that they were dealt into, followed by their chips after each such round.
+// Only the most recent MaxRoundHistory rounds are kept in the history.
+func (bg *Game) ChipHistory(name string) []int {
+	var chips []int
+	for _, record := range bg.history {
+		for _, seat := range record.Seats {
+			if seat.Name != name {
+				continue
+			}
+			if len(chips) == 0 {
+				chips = append(chips, seat.Chips)
+			}
+			chips = append(chips, seat.Chips+seat.Net())
+		}
+	}
+	return chips
+}
@@ -0,0 +1,134 @@
+package blackjack
+
+import (
+	"fmt"
+	"sync"
+)
+
+// HistoryStore persists the records of completed rounds
+type HistoryStore interface {
+	// SaveRound stores the record of a completed round played in the given game
+	SaveRound(gameID string, record *RoundRecord) error
+	// LoadRounds returns the stored rounds for the given game, oldest first
+	LoadRounds(gameID string) ([]*RoundRecord, error)
+}
+
+// StatsStore persists per-player statistics
+type StatsStore interface {
+	// LoadStats returns the stored statistics for the named player. A player without
+	// any stored statistics gets empty statistics rather than an error.
+	LoadStats(name string) (PlayerStats, error)
+	// SaveStats stores the statistics for a player, replacing any existing statistics
+	SaveStats(stats PlayerStats) error
+}
+
+// Recorder is a listener that saves each completed round to a history store and
+// adds its results to the statistics of the players in a stats store. Either store
+// may be nil.
+type Recorder struct {
+	history HistoryStore
+	stats   StatsStore
+	err     error
+}
+
+// NewRecorder creates a recorder that writes to the given stores
+func NewRecorder(history HistoryStore, stats StatsStore) *Recorder {
+	return &Recorder{history: history, stats: stats}
+}
+
+// OnEvent records each completed round
+func (r *Recorder) OnEvent(event Event) {
+	if event.Type != EventRoundCompleted || event.Record == nil {
+		return
+	}
+	if err := r.RecordRound(event.GameID, event.Record); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// Err returns the first error encountered while recording rounds from game events
+func (r *Recorder) Err() error {
+	return r.err
+}
+
+// RecordRound saves the round and updates the statistics of every player dealt into it
+func (r *Recorder) RecordRound(gameID string, record *RoundRecord) error {
+	if r.history != nil {
+		if err := r.history.SaveRound(gameID, record); err != nil {
+			return fmt.Errorf("failed to save round %d: %w", record.ID, err)
+		}
+	}
+	if r.stats == nil {
+		return nil
+	}
+	for _, seat := range record.Seats {
+		stats, err := r.stats.LoadStats(seat.Name)
+		if err != nil {
+			return fmt.Errorf("failed to load stats for %s: %w", seat.Name, err)
+		}
+		stats.Name = seat.Name
+		stats.AddRound(seat)
+		if err := r.stats.SaveStats(stats); err != nil {
+			return fmt.Errorf("failed to save stats for %s: %w", seat.Name, err)
+		}
+	}
+	return nil
+}
+
+// MemoryHistoryStore is a HistoryStore that keeps rounds in memory
+type MemoryHistoryStore struct {
+	mu     sync.RWMutex
+	rounds map[string][]*RoundRecord
+}
+
+// NewMemoryHistoryStore creates an empty in-memory history store
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{rounds: make(map[string][]*RoundRecord)}
+}
+
+// SaveRound stores the record of a completed round
+func (s *MemoryHistoryStore) SaveRound(gameID string, record *RoundRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rounds[gameID] = append(s.rounds[gameID], record)
+	return nil
+}
+
+// LoadRounds returns the stored rounds for the given game, oldest first
+func (s *MemoryHistoryStore) LoadRounds(gameID string) ([]*RoundRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rounds := make([]*RoundRecord, len(s.rounds[gameID]))
+	copy(rounds, s.rounds[gameID])
+	return rounds, nil
+}
+
+// MemoryStatsStore is a StatsStore that keeps statistics in memory
+type MemoryStatsStore struct {
+	mu    sync.RWMutex
+	stats map[string]PlayerStats
+}
+
+// NewMemoryStatsStore creates an empty in-memory stats store
+func NewMemoryStatsStore() *MemoryStatsStore {
+	return &MemoryStatsStore{stats: make(map[string]PlayerStats)}
+}
+
+// LoadStats returns the stored statistics for the named player
+func (s *MemoryStatsStore) LoadStats(name string) (PlayerStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stats, ok := s.stats[name]
+	if !ok {
+		return PlayerStats{Name: name}, nil
+	}
+	return stats, nil
+}
+
+// SaveStats stores the statistics for a player
+func (s *MemoryStatsStore) SaveStats(stats PlayerStats) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats[stats.Name] = stats
+	return nil
+}
@@ -0,0 +1,212 @@
+// Package sqlite provides SQLite-backed implementations of the blackjack history
+// and statistics stores.
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/rbrabson/blackjack"
+	_ "modernc.org/sqlite" // registers the "sqlite" database driver
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS rounds (
+	game_id    TEXT    NOT NULL,
+	round_id   INTEGER NOT NULL,
+	number     INTEGER NOT NULL,
+	started_at TEXT    NOT NULL,
+	ended_at   TEXT    NOT NULL,
+	record     TEXT    NOT NULL,
+	PRIMARY KEY (game_id, round_id)
+);
+CREATE TABLE IF NOT EXISTS player_stats (
+	name          TEXT PRIMARY KEY,
+	rounds        INTEGER NOT NULL,
+	hands         INTEGER NOT NULL,
+	wins          INTEGER NOT NULL,
+	losses        INTEGER NOT NULL,
+	pushes        INTEGER NOT NULL,
+	blackjacks    INTEGER NOT NULL,
+	busts         INTEGER NOT NULL,
+	surrenders    INTEGER NOT NULL,
+	doubles_won   INTEGER NOT NULL,
+	splits_won    INTEGER NOT NULL,
+	total_wagered INTEGER NOT NULL,
+	net           INTEGER NOT NULL,
+	biggest_win   INTEGER NOT NULL DEFAULT 0,
+	charlies      INTEGER NOT NULL DEFAULT 0
+);
+`
+
+// Store is a history and statistics store backed by a SQLite database
+type Store struct {
+	db    *sql.DB
+	owned bool // owned is true if the store opened the database and should close it
+}
+
+// Open opens or creates the SQLite database at path and prepares it for use as a store
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	// SQLite permits a single writer, so serialize access through one connection
+	db.SetMaxOpenConns(1)
+	store, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	store.owned = true
+	return store, nil
+}
+
+// New creates a store on an already open SQLite database, creating its tables if needed
+func New(db *sql.DB) (*Store, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+	if err := addColumn(db, "player_stats", "biggest_win", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(db, "player_stats", "charlies", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// addColumn adds a column to a table created before the column was part of the
+// schema
+func addColumn(db *sql.DB, table, column, definition string) error {
+	rows, err := db.Query(fmt.Sprintf("SELECT name FROM pragma_table_info('%s')", table))
+	if err != nil {
+		return fmt.Errorf("failed to read the columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("failed to read the columns of %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read the columns of %s: %w", table, err)
+	}
+	rows.Close()
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add %s to %s: %w", column, table, err)
+	}
+	return nil
+}
+
+// Close closes the database if it was opened by the store
+func (s *Store) Close() error {
+	if !s.owned {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// SaveRound stores the record of a completed round played in the given game
+func (s *Store) SaveRound(gameID string, record *blackjack.RoundRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode round %d: %w", record.ID, err)
+	}
+	_, err = s.db.Exec(
+		`INSERT OR REPLACE INTO rounds (game_id, round_id, number, started_at, ended_at, record) VALUES (?, ?, ?, ?, ?, ?)`,
+		gameID, record.ID, record.Number, record.StartedAt, record.EndedAt, string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save round %d: %w", record.ID, err)
+	}
+	return nil
+}
+
+// LoadRounds returns the stored rounds for the given game, oldest first
+func (s *Store) LoadRounds(gameID string) ([]*blackjack.RoundRecord, error) {
+	rows, err := s.db.Query(`SELECT record FROM rounds WHERE game_id = ? ORDER BY round_id`, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rounds for game %s: %w", gameID, err)
+	}
+	defer rows.Close()
+
+	var rounds []*blackjack.RoundRecord
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read round: %w", err)
+		}
+		record := &blackjack.RoundRecord{}
+		if err := json.Unmarshal([]byte(data), record); err != nil {
+			return nil, fmt.Errorf("failed to decode round: %w", err)
+		}
+		rounds = append(rounds, record)
+	}
+	return rounds, rows.Err()
+}
+
+// LoadStats returns the stored statistics for the named player
+func (s *Store) LoadStats(name string) (blackjack.PlayerStats, error) {
+	stats := blackjack.PlayerStats{Name: name}
+	err := s.db.QueryRow(
+		`SELECT rounds, hands, wins, losses, pushes, blackjacks, busts, surrenders, doubles_won, splits_won, total_wagered, net, biggest_win, charlies
+		FROM player_stats WHERE name = ?`, name,
+	).Scan(
+		&stats.Rounds, &stats.Hands, &stats.Wins, &stats.Losses, &stats.Pushes, &stats.Blackjacks, &stats.Busts,
+		&stats.Surrenders, &stats.DoublesWon, &stats.SplitsWon, &stats.TotalWagered, &stats.Net, &stats.BiggestWin, &stats.Charlies,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, fmt.Errorf("failed to load stats for %s: %w", name, err)
+	}
+	return stats, nil
+}
+
+// SaveStats stores the statistics for a player, replacing any existing statistics
+func (s *Store) SaveStats(stats blackjack.PlayerStats) error {
+	_, err := s.db.Exec(
+		`INSERT OR REPLACE INTO player_stats
+		(name, rounds, hands, wins, losses, pushes, blackjacks, busts, surrenders, doubles_won, splits_won, total_wagered, net, biggest_win, charlies)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		stats.Name, stats.Rounds, stats.Hands, stats.Wins, stats.Losses, stats.Pushes, stats.Blackjacks, stats.Busts,
+		stats.Surrenders, stats.DoublesWon, stats.SplitsWon, stats.TotalWagered, stats.Net, stats.BiggestWin, stats.Charlies,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save stats for %s: %w", stats.Name, err)
+	}
+	return nil
+}
+
+// Players returns the names of every player with stored statistics
+func (s *Store) Players() ([]string, error) {
+	rows, err := s.db.Query(`SELECT name FROM player_stats ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list players: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read player: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+var (
+	_ blackjack.HistoryStore = (*Store)(nil)
+	_ blackjack.StatsStore   = (*Store)(nil)
+)
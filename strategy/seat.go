@@ -0,0 +1,80 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/rbrabson/blackjack"
+)
+
+// Strategy chooses the action to take on the hand a seat is asked to act on
+type Strategy func(decision blackjack.ActionDecision) (Advice, error)
+
+// strategies are the strategies that may be looked up by name
+var strategies = map[string]Strategy{
+	"basic":  ForDecision,
+	"dealer": MimicDealer,
+}
+
+// Lookup returns the strategy with the given name, "basic" or "dealer"
+func Lookup(name string) (Strategy, error) {
+	strategy, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q (choose from %v)", name, Names())
+	}
+	return strategy, nil
+}
+
+// Names returns the names of the strategies that may be looked up, in sorted order
+func Names() []string {
+	return slices.Sorted(maps.Keys(strategies))
+}
+
+// MimicDealer plays the hand the way the dealer does, hitting below 17 and
+// standing otherwise
+func MimicDealer(decision blackjack.ActionDecision) (Advice, error) {
+	hand, err := decisionHand(decision)
+	if err != nil {
+		return Advice{}, err
+	}
+	if hand.Value < 17 {
+		return Advice{blackjack.ActionHit, "the dealer hits below 17"}, nil
+	}
+	return Advice{blackjack.ActionStand, "the dealer stands on 17 or more"}, nil
+}
+
+// Seat is a seat for a computer player, who bets a fixed amount on each round and
+// plays their hands by a strategy
+type Seat struct {
+	strategy Strategy
+	bet      int
+}
+
+// NewSeat returns a seat that bets the given amount, kept within the table
+// limits, and plays by the strategy
+func NewSeat(strategy Strategy, bet int) *Seat {
+	return &Seat{strategy: strategy, bet: bet}
+}
+
+// Bet returns the seat's bet, or 0 to sit out if the player can't cover it
+func (s *Seat) Bet(ctx context.Context, decision blackjack.BetDecision) (int, error) {
+	amount := max(s.bet, decision.Rules.MinBet)
+	if decision.Rules.MaxBet > 0 {
+		amount = min(amount, decision.Rules.MaxBet)
+	}
+	if amount > decision.Chips {
+		return 0, nil
+	}
+	return amount, nil
+}
+
+// Act returns the action the seat's strategy chooses for the hand
+func (s *Seat) Act(ctx context.Context, decision blackjack.ActionDecision) (blackjack.ActionType, error) {
+	advice, err := s.strategy(decision)
+	if err != nil {
+		return "", err
+	}
+	return advice.Action, nil
+}
@@ -0,0 +1,317 @@
+// Package strategy recommends blackjack plays using basic strategy for a
+// multi-deck shoe, adjusted for whether the dealer hits soft 17 and whether
+// doubling after a split is allowed.
+package strategy
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/cards"
+)
+
+// Advice is a recommended play and the reason for it
+type Advice struct {
+	Action    blackjack.ActionType // Action is the recommended action
+	Rationale string               // Rationale is a one-line explanation of the recommendation
+}
+
+// String returns the recommended action followed by its rationale
+func (a Advice) String() string {
+	return fmt.Sprintf("%s: %s", ActionName(a.Action), a.Rationale)
+}
+
+// ActionName returns the name of a player action, such as "Double down"
+func ActionName(action blackjack.ActionType) string {
+	switch action {
+	case blackjack.ActionHit:
+		return "Hit"
+	case blackjack.ActionStand:
+		return "Stand"
+	case blackjack.ActionDouble:
+		return "Double down"
+	case blackjack.ActionSplit:
+		return "Split"
+	case blackjack.ActionSurrender:
+		return "Surrender"
+	default:
+		return string(action)
+	}
+}
+
+// position is what basic strategy needs to know about a hand
+type position struct {
+	value   int                             // value is the best value of the hand
+	soft    bool                            // soft is whether an ace is counted as 11
+	first   int                             // first is the value of the first card, counting an ace as 11
+	count   int                             // count is the number of cards in the hand
+	allowed func(blackjack.ActionType) bool // allowed returns whether the hand may take an action
+}
+
+// Recommend returns the basic strategy play for the hand against the dealer's up
+// card. Only actions the hand may take are recommended; when basic strategy calls
+// for a double down or surrender that isn't allowed, the next best play is
+// recommended instead.
+func Recommend(hand *blackjack.Hand, upCard cards.Card, rules blackjack.Rules) Advice {
+	_, _, soft := hand.Values()
+	p := position{
+		value: hand.Value(),
+		soft:  soft,
+		count: hand.Count(),
+		allowed: func(action blackjack.ActionType) bool {
+			switch action {
+			case blackjack.ActionDouble:
+				return hand.CanDoubleDown()
+			case blackjack.ActionSplit:
+				return hand.CanSplit()
+			case blackjack.ActionSurrender:
+				return hand.CanSurrender()
+			default:
+				return true
+			}
+		},
+	}
+	if p.count > 0 {
+		p.first = cardValue(hand.Cards()[0])
+	}
+	return recommend(p, cardValue(upCard), rules)
+}
+
+// ForDecision returns the basic strategy play for the hand a seat is asked to act
+// on, recommending only the actions in the decision
+func ForDecision(decision blackjack.ActionDecision) (Advice, error) {
+	hand, err := decisionHand(decision)
+	if err != nil {
+		return Advice{}, err
+	}
+	dealer := decision.State.Dealer.Cards
+	if len(dealer) == 0 || dealer[0] == nil {
+		return Advice{}, fmt.Errorf("the dealer has no up card")
+	}
+
+	p := position{
+		value: hand.Value,
+		soft:  hand.Soft,
+		first: cardValue(hand.Cards[0]),
+		count: len(hand.Cards),
+		allowed: func(action blackjack.ActionType) bool {
+			return slices.Contains(decision.Actions, action)
+		},
+	}
+	return recommend(p, cardValue(*dealer[0]), decision.Rules), nil
+}
+
+// Opening returns the basic strategy play for a hand of two cards, before it has
+// been acted on, against the dealer's up card. The hand may double down on any two
+// cards, split a pair, and surrender unless the rules forbid it.
+func Opening(first, second, upCard cards.Card, rules blackjack.Rules) Advice {
+	value, soft := cardValue(first)+cardValue(second), first.Rank == cards.Ace || second.Rank == cards.Ace
+	if value > 21 {
+		// A pair of aces counts one of them as 1
+		value -= 10
+	}
+	p := position{
+		value: value,
+		soft:  soft,
+		first: cardValue(first),
+		count: 2,
+		allowed: func(action blackjack.ActionType) bool {
+			switch action {
+			case blackjack.ActionSplit:
+				return first.Rank == second.Rank
+			case blackjack.ActionSurrender:
+				return !rules.NoSurrender
+			default:
+				return true
+			}
+		},
+	}
+	return recommend(p, cardValue(upCard), rules)
+}
+
+// decisionHand returns the hand a seat is asked to act on
+func decisionHand(decision blackjack.ActionDecision) (blackjack.HandState, error) {
+	for _, player := range decision.State.Players {
+		if player.Name == decision.Player && decision.Hand >= 0 && decision.Hand < len(player.Hands) {
+			if hand := player.Hands[decision.Hand]; len(hand.Cards) > 0 {
+				return hand, nil
+			}
+		}
+	}
+	return blackjack.HandState{}, fmt.Errorf("no hand %d for player %s", decision.Hand, decision.Player)
+}
+
+// recommend returns the basic strategy play for a hand against the value of the
+// dealer's up card
+func recommend(p position, up int, rules blackjack.Rules) Advice {
+	if advice, ok := surrender(p, up, rules); ok {
+		return advice
+	}
+	if p.allowed(blackjack.ActionSplit) {
+		if advice, ok := split(p, up, rules); ok {
+			return advice
+		}
+	}
+	if !p.allowed(blackjack.ActionHit) {
+		return Advice{blackjack.ActionStand, "split aces receive only one card"}
+	}
+	if p.soft {
+		return softTotal(p, up, rules)
+	}
+	return hardTotal(p, up, rules)
+}
+
+// surrender returns the advice to surrender, if basic strategy calls for it
+func surrender(p position, up int, rules blackjack.Rules) (Advice, bool) {
+	if p.soft || !p.allowed(blackjack.ActionSurrender) || p.count != 2 {
+		return Advice{}, false
+	}
+	if p.allowed(blackjack.ActionSplit) && p.first == 8 {
+		// A pair of eights is split rather than surrendered
+		return Advice{}, false
+	}
+
+	h17 := !rules.StandSoft17
+	switch {
+	case p.value == 16 && up >= 9,
+		p.value == 15 && (up == 10 || (up == 11 && h17)),
+		p.value == 17 && up == 11 && h17:
+		return Advice{blackjack.ActionSurrender, fmt.Sprintf("hard %d loses more than half the time against the dealer's %s", p.value, upLabel(up))}, true
+	}
+	return Advice{}, false
+}
+
+// split returns the advice to split a pair, if basic strategy calls for it
+func split(p position, up int, rules blackjack.Rules) (Advice, bool) {
+	das := !rules.NoDoubleAfterSplit
+	weak := fmt.Sprintf("the dealer's %s is weak, so play two hands against it", upLabel(up))
+
+	switch p.first {
+	case 11:
+		return Advice{blackjack.ActionSplit, "each ace starts a strong hand of its own"}, true
+	case 8:
+		return Advice{blackjack.ActionSplit, "16 is the worst total, but each 8 starts a fair hand"}, true
+	case 9:
+		if up <= 9 && up != 7 {
+			return Advice{blackjack.ActionSplit, fmt.Sprintf("two hands starting with 9 beat the dealer's %s more often than 18 does", upLabel(up))}, true
+		}
+	case 7:
+		if up <= 7 {
+			return Advice{blackjack.ActionSplit, weak}, true
+		}
+	case 6:
+		if up <= 6 && (das || up >= 3) {
+			return Advice{blackjack.ActionSplit, weak}, true
+		}
+	case 4:
+		if das && (up == 5 || up == 6) {
+			return Advice{blackjack.ActionSplit, weak}, true
+		}
+	case 2, 3:
+		if up <= 7 && (das || up >= 4) {
+			return Advice{blackjack.ActionSplit, weak}, true
+		}
+	}
+	return Advice{}, false
+}
+
+// softTotal returns the advice for a hand with an ace counted as 11
+func softTotal(p position, up int, rules blackjack.Rules) Advice {
+	value := p.value
+	h17 := !rules.StandSoft17
+	stand := Advice{blackjack.ActionStand, fmt.Sprintf("soft %d beats most of what the dealer's %s makes", value, upLabel(up))}
+	hit := Advice{blackjack.ActionHit, fmt.Sprintf("soft %d can't bust with one more card", value)}
+	double := fmt.Sprintf("soft %d can't bust and the dealer's %s is weak", value, upLabel(up))
+
+	switch {
+	case value >= 20:
+		return Advice{blackjack.ActionStand, fmt.Sprintf("soft %d is already strong", value)}
+	case value == 19:
+		if h17 && up == 6 {
+			return doubleOr(p, double, stand)
+		}
+		return stand
+	case value == 18:
+		switch {
+		case up <= 6 && (h17 || up >= 3):
+			return doubleOr(p, double, stand)
+		case up <= 8:
+			return stand
+		default:
+			return Advice{blackjack.ActionHit, fmt.Sprintf("soft 18 is an underdog to the dealer's %s and can't bust with one card", upLabel(up))}
+		}
+	case value == 17:
+		if up >= 3 && up <= 6 {
+			return doubleOr(p, double, hit)
+		}
+	case value >= 15:
+		if up >= 4 && up <= 6 {
+			return doubleOr(p, double, hit)
+		}
+	case value >= 13:
+		if up >= 5 && up <= 6 {
+			return doubleOr(p, double, hit)
+		}
+	}
+	return hit
+}
+
+// hardTotal returns the advice for a hand without an ace counted as 11
+func hardTotal(p position, up int, rules blackjack.Rules) Advice {
+	value := p.value
+	hit := Advice{blackjack.ActionHit, fmt.Sprintf("%d can't bust with one more card", value)}
+
+	switch {
+	case value >= 17:
+		return Advice{blackjack.ActionStand, fmt.Sprintf("hard %d is too likely to bust if you hit", value)}
+	case value >= 13 && up <= 6, value == 12 && up >= 4 && up <= 6:
+		return Advice{blackjack.ActionStand, fmt.Sprintf("the dealer's %s is weak and likely to bust", upLabel(up))}
+	case value >= 12 && up >= 7:
+		return Advice{blackjack.ActionHit, fmt.Sprintf("the dealer's %s is strong, so %d is unlikely to win as it stands", upLabel(up), value)}
+	case value == 12:
+		return Advice{blackjack.ActionHit, fmt.Sprintf("12 seldom busts and the dealer's %s busts less often than a 4, 5, or 6", upLabel(up))}
+	case value == 11:
+		if up <= 10 || !rules.StandSoft17 {
+			return doubleOr(p, fmt.Sprintf("11 is likely to draw to a strong hand against the dealer's %s", upLabel(up)), hit)
+		}
+	case value == 10:
+		if up <= 9 {
+			return doubleOr(p, fmt.Sprintf("10 is likely to draw to a strong hand against the dealer's %s", upLabel(up)), hit)
+		}
+	case value == 9:
+		if up >= 3 && up <= 6 {
+			return doubleOr(p, fmt.Sprintf("the dealer's %s is weak, so press the advantage", upLabel(up)), hit)
+		}
+	}
+	return hit
+}
+
+// doubleOr returns the advice to double down if the hand may, or the fallback
+// advice if it may not
+func doubleOr(p position, rationale string, fallback Advice) Advice {
+	if p.allowed(blackjack.ActionDouble) {
+		return Advice{blackjack.ActionDouble, rationale}
+	}
+	return fallback
+}
+
+// cardValue returns the value of a card, counting an ace as 11
+func cardValue(card cards.Card) int {
+	switch card.Rank {
+	case cards.Ace:
+		return 11
+	case cards.Jack, cards.Queen, cards.King:
+		return 10
+	default:
+		return int(card.Rank)
+	}
+}
+
+// upLabel returns how the dealer's up card is described in a rationale
+func upLabel(up int) string {
+	if up == 11 {
+		return "ace"
+	}
+	return fmt.Sprint(up)
+}
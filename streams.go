@@ -0,0 +1,48 @@
+package blackjack
+
+import "math/rand/v2"
+
+// Streams derives independent random number streams from a master seed, one for
+// each worker of a parallel simulation. Each stream depends only on the master seed
+// and its index, so a simulation gives the same results however its work is
+// scheduled, as long as each piece of work always uses the same stream.
+type Streams struct {
+	master uint64 // master is the seed every stream is derived from
+}
+
+// NewStreams returns the streams derived from the master seed
+func NewStreams(master uint64) Streams {
+	return Streams{master: master}
+}
+
+// Seed returns the seed of a stream, such as for seeding a worker's game with
+// WithSeed
+func (s Streams) Seed(stream int) uint64 {
+	return splitMix(s.master + uint64(stream)*goldenGamma)
+}
+
+// Rand returns a random number generator for a stream. The generator is not safe
+// for concurrent use, so each worker should have its own.
+func (s Streams) Rand(stream int) *rand.Rand {
+	seed := s.Seed(stream)
+	return rand.New(rand.NewPCG(seed, splitMix(seed)))
+}
+
+// Split returns the streams derived from a stream, for work that itself divides
+// into independent pieces
+func (s Streams) Split(stream int) Streams {
+	return Streams{master: s.Seed(stream)}
+}
+
+// goldenGamma spaces the inputs of splitMix so that consecutive streams are far
+// apart
+const goldenGamma = 0x9e3779b97f4a7c15
+
+// splitMix scrambles a value with the SplitMix64 finalizer, so that related inputs
+// give unrelated seeds
+func splitMix(x uint64) uint64 {
+	x += goldenGamma
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
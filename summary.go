@@ -0,0 +1,78 @@
+package blackjack
+
+import "strings"
+
+// RoundSummary is the outcome of a completed round for each player dealt into it,
+// in a form any frontend can present
+type RoundSummary struct {
+	Round   int             `json:"round"`   // Round is the round number within the session
+	Players []PlayerSummary `json:"players"` // Players are the outcomes for each player, in seat order
+}
+
+// PlayerSummary is the outcome of a completed round for a single player
+type PlayerSummary struct {
+	Name  string        `json:"name"`  // Name is the player's name
+	Hands []HandSummary `json:"hands"` // Hands are the outcomes of the player's hands, including any split hands
+	Net   int           `json:"net"`   // Net is the amount won across all of the player's hands (negative for a loss)
+	Chips int           `json:"chips"` // Chips is the player's balance once the round was settled
+}
+
+// HandSummary is the outcome of a single settled hand
+type HandSummary struct {
+	Label    string     `json:"label"`    // Label is the name of the hand, such as "Hand 2 (split from Hand 1)"
+	Result   GameResult `json:"result"`   // Result is the outcome of the hand
+	Bet      int        `json:"bet"`      // Bet is the final bet on the hand, including any double down
+	Winnings int        `json:"winnings"` // Winnings is the net amount won (negative for a loss)
+}
+
+// Summary returns the outcome of the round for each player dealt into it
+func (r *RoundRecord) Summary() RoundSummary {
+	summary := RoundSummary{Round: r.Number, Players: make([]PlayerSummary, 0, len(r.Seats))}
+	for _, seat := range r.Seats {
+		player := PlayerSummary{Name: seat.Name, Net: seat.Net(), Chips: seat.Chips + seat.Net()}
+		for _, hand := range seat.Hands {
+			player.Hands = append(player.Hands, HandSummary{
+				Label:    hand.Label(),
+				Result:   hand.Result,
+				Bet:      hand.Bet,
+				Winnings: hand.Winnings,
+			})
+		}
+		summary.Players = append(summary.Players, player)
+	}
+	return summary
+}
+
+// Label returns the name of the hand, noting the hand it was split from if any
+func (h HandRecord) Label() string {
+	if h.ParentID != 0 {
+		return Message(MsgHandSplitFrom, h.ID, h.ParentID)
+	}
+	return Message(MsgHandLabel, h.ID)
+}
+
+// String returns the outcome of each player's hands and their balance, one player
+// after another on separate lines
+func (s RoundSummary) String() string {
+	lines := make([]string, 0, len(s.Players))
+	for _, player := range s.Players {
+		lines = append(lines, player.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// String returns the outcome of the player's hands, listing each hand on its own
+// line if the player split, followed by their balance
+func (p PlayerSummary) String() string {
+	var lines []string
+	if len(p.Hands) == 1 {
+		lines = append(lines, Message(MsgSummaryPlayer, p.Name, p.Hands[0].Result))
+	} else {
+		lines = append(lines, Message(MsgSummaryPlayerHands, p.Name))
+		for _, hand := range p.Hands {
+			lines = append(lines, Message(MsgSummaryHand, hand.Label, hand.Result))
+		}
+	}
+	lines = append(lines, Message(MsgSummaryChips, p.Chips))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,81 @@
+package blackjack
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation name of the game's tracer
+const tracerName = "github.com/rbrabson/blackjack"
+
+// WithTracerProvider traces rounds, deals, player actions, and settlements using
+// the tracer provider. Each round is traced as a span that parents the spans of its
+// deal, dealer play, and settlement. Player actions made with ActContext are parented
+// by the caller's span, if any, so a request can be traced from an API layer into the
+// game, and are linked to the round's span.
+func WithTracerProvider(tp trace.TracerProvider) GameOption {
+	return func(g *Game) {
+		g.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// tracerOrNoop returns the game's tracer, or a tracer that records nothing if tracing is not enabled
+func (bg *Game) tracerOrNoop() trace.Tracer {
+	if bg.tracer == nil {
+		return noop.NewTracerProvider().Tracer(tracerName)
+	}
+	return bg.tracer
+}
+
+// startRoundSpan starts the span for a new round, ending the span of any round
+// that was abandoned
+func (bg *Game) startRoundSpan() {
+	bg.endRoundSpan()
+	_, bg.roundSpan = bg.tracerOrNoop().Start(context.Background(), "blackjack.round",
+		trace.WithAttributes(bg.spanAttributes()...),
+		trace.WithAttributes(attribute.Int("blackjack.round.number", bg.round)),
+	)
+}
+
+// endRoundSpan ends the span of the current round, if any
+func (bg *Game) endRoundSpan() {
+	if bg.roundSpan != nil {
+		bg.roundSpan.End()
+		bg.roundSpan = nil
+	}
+}
+
+// startSpan starts a span for an operation in the current round. The span is
+// parented by the span in ctx, if there is one, and otherwise by the round's span.
+func (bg *Game) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	options := []trace.SpanStartOption{trace.WithAttributes(bg.spanAttributes()...), trace.WithAttributes(attrs...)}
+	if bg.roundSpan != nil {
+		if trace.SpanContextFromContext(ctx).IsValid() {
+			options = append(options, trace.WithLinks(trace.Link{SpanContext: bg.roundSpan.SpanContext()}))
+		} else {
+			ctx = trace.ContextWithSpan(ctx, bg.roundSpan)
+		}
+	}
+	return bg.tracerOrNoop().Start(ctx, name, options...)
+}
+
+// spanAttributes returns the attributes that identify the game and round
+func (bg *Game) spanAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("blackjack.game.id", bg.id),
+		attribute.Int64("blackjack.round.id", bg.roundID),
+	}
+}
+
+// endSpan records the error, if any, on the span and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,249 @@
+// Package webhook posts a settlement summary to configured URLs whenever a round
+// of blackjack completes.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rbrabson/blackjack"
+	"github.com/rbrabson/cards"
+)
+
+const (
+	DefaultRetries   = 3                       // DefaultRetries is the number of times a failed delivery is retried
+	DefaultTimeout   = 10 * time.Second        // DefaultTimeout is the time allowed for a single delivery attempt
+	DefaultQueueSize = 100                     // DefaultQueueSize is the number of summaries queued before new ones are dropped
+	SignatureHeader  = "X-Blackjack-Signature" // SignatureHeader carries the HMAC-SHA256 signature of a signed delivery
+	EventHeader      = "X-Blackjack-Event"     // EventHeader carries the type of event being delivered
+)
+
+// Summary is the body posted to a webhook when a round completes
+type Summary struct {
+	GameID      string          `json:"game_id"`
+	RoundID     int64           `json:"round_id"`
+	Round       int             `json:"round"`
+	StartedAt   time.Time       `json:"started_at"`
+	SettledAt   time.Time       `json:"settled_at"`
+	DealerCards []cards.Card    `json:"dealer_cards"`
+	Players     []PlayerSummary `json:"players"`
+}
+
+// PlayerSummary is a player's results in a completed round
+type PlayerSummary struct {
+	Name  string        `json:"name"`
+	Hands []HandSummary `json:"hands"`
+	Net   int           `json:"net"` // Net is the player's net winnings for the round (negative for a loss)
+}
+
+// HandSummary is the result of a single settled hand
+type HandSummary struct {
+	Spot   int    `json:"spot"`
+	Bet    int    `json:"bet"`
+	Result string `json:"result"`
+	Payout int    `json:"payout"` // Payout is the net amount won on the hand (negative for a loss)
+}
+
+// NewSummary creates the summary of a completed round
+func NewSummary(gameID string, record *blackjack.RoundRecord) Summary {
+	summary := Summary{
+		GameID:      gameID,
+		RoundID:     record.ID,
+		Round:       record.Number,
+		StartedAt:   record.StartedAt,
+		SettledAt:   record.EndedAt,
+		DealerCards: record.DealerCards,
+		Players:     make([]PlayerSummary, 0, len(record.Seats)),
+	}
+	for _, seat := range record.Seats {
+		player := PlayerSummary{Name: seat.Name, Hands: make([]HandSummary, 0, len(seat.Hands))}
+		for _, hand := range seat.Hands {
+			player.Hands = append(player.Hands, HandSummary{
+				Spot:   hand.Spot,
+				Bet:    hand.Bet,
+				Result: hand.Result.String(),
+				Payout: hand.Winnings,
+			})
+			player.Net += hand.Winnings
+		}
+		summary.Players = append(summary.Players, player)
+	}
+	return summary
+}
+
+// Notifier is a game listener that posts a Summary to each of its URLs when a round
+// completes. Deliveries are made in the background so that a slow endpoint never
+// holds up the game.
+type Notifier struct {
+	urls    []string
+	client  *http.Client
+	secret  []byte
+	retries int
+	onError func(url string, summary Summary, err error)
+
+	mu     sync.Mutex // mu guards closing the queue
+	closed bool
+	queue  chan Summary
+	done   chan struct{}
+}
+
+// Option is a function that configures a notifier
+type Option func(*Notifier)
+
+// WithHTTPClient sets the HTTP client used to deliver summaries
+func WithHTTPClient(client *http.Client) Option {
+	return func(n *Notifier) {
+		n.client = client
+	}
+}
+
+// WithSecret signs each delivery with an HMAC-SHA256 of the body, sent hex encoded
+// in the X-Blackjack-Signature header, so receivers can verify the sender
+func WithSecret(secret string) Option {
+	return func(n *Notifier) {
+		n.secret = []byte(secret)
+	}
+}
+
+// WithRetries sets the number of times a failed delivery is retried
+func WithRetries(retries int) Option {
+	return func(n *Notifier) {
+		n.retries = max(0, retries)
+	}
+}
+
+// WithQueueSize sets the number of summaries that may be waiting for delivery
+func WithQueueSize(size int) Option {
+	return func(n *Notifier) {
+		n.queue = make(chan Summary, max(1, size))
+	}
+}
+
+// WithErrorHandler sets a function called when a summary cannot be delivered to a URL
+func WithErrorHandler(handler func(url string, summary Summary, err error)) Option {
+	return func(n *Notifier) {
+		n.onError = handler
+	}
+}
+
+// New creates a notifier that posts to the given URLs and starts delivering summaries
+func New(urls []string, options ...Option) *Notifier {
+	n := &Notifier{
+		urls:    urls,
+		client:  &http.Client{Timeout: DefaultTimeout},
+		retries: DefaultRetries,
+		queue:   make(chan Summary, DefaultQueueSize),
+		done:    make(chan struct{}),
+	}
+	for _, option := range options {
+		option(n)
+	}
+	go n.deliverLoop()
+	return n
+}
+
+// OnEvent queues a summary of each completed round for delivery. If the queue is
+// full the summary is dropped and reported to the error handler.
+func (n *Notifier) OnEvent(event blackjack.Event) {
+	if event.Type != blackjack.EventRoundCompleted || event.Record == nil {
+		return
+	}
+	summary := NewSummary(event.GameID, event.Record)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return
+	}
+	select {
+	case n.queue <- summary:
+	default:
+		n.failed("", summary, fmt.Errorf("webhook queue is full, dropping round %d", summary.RoundID))
+	}
+}
+
+// Close stops accepting summaries and waits for queued summaries to be delivered
+func (n *Notifier) Close() {
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+	<-n.done
+}
+
+// deliverLoop delivers queued summaries until the notifier is closed
+func (n *Notifier) deliverLoop() {
+	defer close(n.done)
+	for summary := range n.queue {
+		body, err := json.Marshal(summary)
+		if err != nil {
+			n.failed("", summary, fmt.Errorf("failed to encode summary: %w", err))
+			continue
+		}
+		for _, url := range n.urls {
+			if err := n.deliver(url, body); err != nil {
+				n.failed(url, summary, err)
+			}
+		}
+	}
+}
+
+// deliver posts the body to the URL, retrying with a growing delay if it fails
+func (n *Notifier) deliver(url string, body []byte) error {
+	var err error
+	delay := 500 * time.Millisecond
+	for attempt := 0; attempt <= n.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+		if err = n.post(url, body); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// post makes a single delivery attempt
+func (n *Notifier) post(url string, body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(blackjack.EventRoundCompleted))
+	if len(n.secret) > 0 {
+		mac := hmac.New(sha256.New, n.secret)
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// failed reports a summary that could not be delivered
+func (n *Notifier) failed(url string, summary Summary, err error) {
+	if n.onError != nil {
+		n.onError(url, summary, err)
+		return
+	}
+	slog.Error("failed to deliver webhook", "url", url, "game", summary.GameID, "round", summary.RoundID, "error", err)
+}